@@ -0,0 +1,301 @@
+// Package admin provides an HTTP handler to inspect and control the tasks at
+// runtime.
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/parametalol/goticks"
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+// DefaultHistory is the number of the recent runs, kept per task by default.
+const DefaultHistory = 10
+
+// Run is a recorded task execution.
+type Run struct {
+	Start    time.Time `json:"start"`
+	Duration string    `json:"duration"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Status is the state of a task, served as JSON.
+type Status struct {
+	Name    string `json:"name"`
+	Started bool   `json:"started"`
+	Paused  bool   `json:"paused"`
+	// Period is set for the periodic tickers.
+	Period string `json:"period,omitempty"`
+	// NextRun is set for the tickers, that know the time of the next tick.
+	NextRun   *time.Time `json:"next_run,omitempty"`
+	Runs      int64      `json:"runs"`
+	Failures  int64      `json:"failures"`
+	LastError string     `json:"last_error,omitempty"`
+	// History keeps the recent runs, the oldest first.
+	History []Run `json:"history"`
+}
+
+// Task is a task, controlled by the handler.
+type Task interface {
+	ticker.Restartable
+	goticks.Triggerable
+	Started() bool
+}
+
+type entry struct {
+	// task and ticker are nil until the task is registered.
+	task   Task
+	ticker any
+
+	runs      int64
+	failures  int64
+	lastError string
+	history   []Run
+}
+
+type options struct {
+	history int
+}
+
+type Option func(*options)
+
+// WithHistory sets the number of the recent runs, kept per task.
+func WithHistory(n int) Option {
+	return func(o *options) {
+		o.history = n
+	}
+}
+
+// Handler serves the task statuses and the control actions:
+//
+//	GET  /                 list the task statuses
+//	GET  /{name}           get the task status
+//	POST /{name}/start     start the task
+//	POST /{name}/stop      stop the task
+//	POST /{name}/pause     pause a [ticker.Pausable] ticker
+//	POST /{name}/resume    resume a [ticker.Pausable] ticker
+//	POST /{name}/trigger   run the task now, in the background
+//	POST /{name}/period    reset a [ticker.Periodic] time ticker to the period
+//	                       form value, e.g. period=5m
+//
+// The actions respond with the task status. Mount the handler with
+// [http.StripPrefix] to serve it under a path.
+type Handler struct {
+	options options
+
+	mux    sync.Mutex
+	tasks  map[string]*entry
+	routes *http.ServeMux
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// New returns a handler without tasks.
+//
+// Example:
+//
+//	h := admin.New()
+//	task := goticks.NewTask(ticker.NewTimer(time.Minute), admin.Record[time.Time](h, "sync", sync))
+//	admin.Register(h, "sync", task)
+//	mux.Handle("/admin/", http.StripPrefix("/admin", h))
+func New(opts ...Option) *Handler {
+	h := &Handler{
+		options: options{history: DefaultHistory},
+		tasks:   make(map[string]*entry),
+		routes:  http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(&h.options)
+	}
+	h.routes.HandleFunc("GET /{$}", h.list)
+	h.routes.HandleFunc("GET /{name}", h.get)
+	h.routes.HandleFunc("POST /{name}/{action}", h.act)
+	return h
+}
+
+// Register adds the task to the handler under the name.
+func Register[TickType any](h *Handler, name string, task goticks.RestartableWithTicker[TickType]) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	e := h.entry(name)
+	e.task = task
+	e.ticker = task.Ticker()
+}
+
+// Record wraps the task to record its runs for the named task status.
+func Record[TickType any, Fn utils.Func[TickType]](h *Handler, name string, task Fn) func(context.Context, TickType) error {
+	adaptedTask := utils.Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		start := time.Now()
+		err := adaptedTask(ctx, tick)
+		h.record(name, start, time.Since(start), err)
+		return err
+	}
+}
+
+// entry returns the named entry, creating it if needed. Must be called with
+// the mutex locked.
+func (h *Handler) entry(name string) *entry {
+	e, ok := h.tasks[name]
+	if !ok {
+		e = &entry{}
+		h.tasks[name] = e
+	}
+	return e
+}
+
+func (h *Handler) record(name string, start time.Time, d time.Duration, err error) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	e := h.entry(name)
+	e.runs++
+	run := Run{Start: start, Duration: d.String()}
+	if err != nil {
+		e.failures++
+		e.lastError = err.Error()
+		run.Error = e.lastError
+	}
+	if h.options.history > 0 {
+		if len(e.history) == h.options.history {
+			e.history = slices.Delete(e.history, 0, 1)
+		}
+		e.history = append(e.history, run)
+	}
+}
+
+// status returns the status of the entry. Must be called with the mutex
+// locked.
+func (e *entry) status(name string) Status {
+	s := Status{
+		Name:      name,
+		Runs:      e.runs,
+		Failures:  e.failures,
+		LastError: e.lastError,
+		History:   slices.Clone(e.history),
+	}
+	if s.History == nil {
+		s.History = []Run{}
+	}
+	if e.task != nil {
+		s.Started = e.task.Started()
+	}
+	if p, ok := e.ticker.(ticker.Pausable); ok {
+		s.Paused = p.Paused()
+	}
+	if p, ok := e.ticker.(ticker.Periodic); ok && p.Period() > 0 {
+		s.Period = p.Period().String()
+	}
+	if sch, ok := e.ticker.(ticker.Scheduled); ok {
+		if next := sch.NextTick(); !next.IsZero() {
+			s.NextRun = &next
+		}
+	}
+	return s
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.routes.ServeHTTP(w, r)
+}
+
+func (h *Handler) list(w http.ResponseWriter, _ *http.Request) {
+	h.mux.Lock()
+	statuses := make([]Status, 0, len(h.tasks))
+	for _, name := range slices.Sorted(maps.Keys(h.tasks)) {
+		statuses = append(statuses, h.tasks[name].status(name))
+	}
+	h.mux.Unlock()
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	h.mux.Lock()
+	e, ok := h.tasks[name]
+	var s Status
+	if ok {
+		s = e.status(name)
+	}
+	h.mux.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s)
+}
+
+func (h *Handler) act(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	h.mux.Lock()
+	e, ok := h.tasks[name]
+	var task Task
+	var t any
+	if ok {
+		task, t = e.task, e.ticker
+	}
+	h.mux.Unlock()
+	if task == nil {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+
+	code := http.StatusOK
+	switch r.PathValue("action") {
+	case "start":
+		task.Start()
+	case "stop":
+		task.Stop()
+	case "pause", "resume":
+		p, ok := t.(ticker.Pausable)
+		if !ok {
+			writeError(w, http.StatusConflict, "ticker is not pausable")
+			return
+		}
+		if r.PathValue("action") == "pause" {
+			p.Pause()
+		} else {
+			p.Resume()
+		}
+	case "trigger":
+		go func() {
+			_ = task.Trigger(context.WithoutCancel(r.Context()))
+		}()
+		code = http.StatusAccepted
+	case "period":
+		d, err := time.ParseDuration(r.FormValue("period"))
+		if err != nil || d <= 0 {
+			writeError(w, http.StatusBadRequest, "bad period")
+			return
+		}
+		tt, isTime := t.(ticker.TimeTicker)
+		p, isPeriodic := t.(ticker.Periodic)
+		if !isTime || !isPeriodic || p.Period() == 0 {
+			writeError(w, http.StatusConflict, "ticker is not periodic")
+			return
+		}
+		tt.Reset(d)
+	default:
+		writeError(w, http.StatusNotFound, "unknown action")
+		return
+	}
+	h.mux.Lock()
+	s := e.status(name)
+	h.mux.Unlock()
+	writeJSON(w, code, s)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]string{"error": msg})
+}
@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks"
+	"github.com/parametalol/goticks/ticker"
+)
+
+func do[T any](t *testing.T, h http.Handler, method, path string, form url.Values) (int, T) {
+	t.Helper()
+	r := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	if form != nil {
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	var v T
+	if err := json.NewDecoder(w.Body).Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	return w.Code, v
+}
+
+func TestHandler(t *testing.T) {
+	h := New(WithHistory(2))
+	var runs atomic.Int32
+	timer := ticker.NewTimer(time.Hour)
+	task := goticks.NewTask(timer, Record[time.Time](h, "timer", func() error {
+		if runs.Add(1) > 1 {
+			return errors.New("oops")
+		}
+		return nil
+	}), goticks.WithTickerStop())
+	Register(h, "timer", task)
+	manual := ticker.New[int]()
+	Register(h, "manual", goticks.NewTask(manual, func() {}))
+	defer task.Stop()
+
+	code, statuses := do[[]Status](t, h, "GET", "/", nil)
+	assert.That(t,
+		assert.Equal(http.StatusOK, code),
+		assert.Equal(2, len(statuses)),
+		assert.Equal("manual", statuses[0].Name),
+		assert.Equal("timer", statuses[1].Name),
+		assert.False(statuses[1].Started),
+		assert.Equal("1h0m0s", statuses[1].Period))
+
+	code, status := do[Status](t, h, "POST", "/timer/start", nil)
+	time.Sleep(50 * time.Millisecond)
+	assert.That(t,
+		assert.Equal(http.StatusOK, code),
+		assert.True(status.Started),
+		assert.Equal(int32(1), runs.Load()))
+
+	code, status = do[Status](t, h, "POST", "/timer/period", url.Values{"period": {"30m"}})
+	assert.That(t,
+		assert.Equal(http.StatusOK, code),
+		assert.Equal("30m0s", status.Period),
+		assert.True(status.NextRun != nil && time.Until(*status.NextRun) > 29*time.Minute))
+
+	code, status = do[Status](t, h, "POST", "/timer/pause", nil)
+	assert.That(t,
+		assert.Equal(http.StatusOK, code),
+		assert.True(status.Paused))
+	_, status = do[Status](t, h, "POST", "/timer/resume", nil)
+	assert.That(t, assert.False(status.Paused))
+
+	for range 2 {
+		code, _ = do[Status](t, h, "POST", "/timer/trigger", nil)
+		assert.That(t, assert.Equal(http.StatusAccepted, code))
+		time.Sleep(20 * time.Millisecond)
+	}
+	code, status = do[Status](t, h, "GET", "/timer", nil)
+	assert.That(t,
+		assert.Equal(http.StatusOK, code),
+		assert.Equal(int64(3), status.Runs),
+		assert.Equal(int64(2), status.Failures),
+		assert.Equal("oops", status.LastError),
+		assert.Equal(2, len(status.History)))
+
+	_, status = do[Status](t, h, "POST", "/timer/stop", nil)
+	assert.That(t,
+		assert.False(status.Started),
+		assert.True(status.NextRun == nil))
+}
+
+func TestHandler_errors(t *testing.T) {
+	h := New()
+	Register(h, "manual", goticks.NewTask(ticker.New[int](), func() {}))
+	for _, c := range []struct {
+		method, path string
+		form         url.Values
+		code         int
+	}{
+		{"GET", "/missing", nil, http.StatusNotFound},
+		{"POST", "/missing/start", nil, http.StatusNotFound},
+		{"POST", "/manual/jump", nil, http.StatusNotFound},
+		{"POST", "/manual/pause", nil, http.StatusConflict},
+		{"POST", "/manual/period", url.Values{"period": {"1m"}}, http.StatusConflict},
+		{"POST", "/manual/period", url.Values{"period": {"x"}}, http.StatusBadRequest},
+	} {
+		code, body := do[map[string]string](t, h, c.method, c.path, c.form)
+		assert.That(t,
+			assert.Equal(c.code, code),
+			assert.True(body["error"] != ""))
+	}
+}
@@ -0,0 +1,200 @@
+// Command goticks runs shell commands on the schedules from a file, as a
+// lightweight cron replacement.
+//
+// Usage:
+//
+//	goticks [-schedule goticks.conf] [-log-format text|json] [-drain-timeout 30s]
+//
+// See parseSchedule for the schedule file format. SIGHUP reloads the schedule
+// file, and SIGTERM or SIGINT stop the scheduling and wait for the running
+// commands to finish, up to the drain timeout, after which the process groups
+// of the commands are killed. The runs of a command do not overlap, unless the
+// task concurrency is set: a run, due while the previous one is running, is
+// skipped.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/parametalol/goticks/scheduler"
+	"github.com/parametalol/goticks/utils"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("goticks", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	path := flags.String("schedule", "goticks.conf", "schedule file")
+	logFormat := flags.String("log-format", "text", "log format: text or json")
+	drainTimeout := flags.Duration("drain-timeout", 30*time.Second, "time to wait for the running commands on stop")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	var handler slog.Handler
+	switch *logFormat {
+	case "text":
+		handler = slog.NewTextHandler(stderr, nil)
+	case "json":
+		handler = slog.NewJSONHandler(stderr, nil)
+	default:
+		_, _ = fmt.Fprintln(stderr, "unknown log format:", *logFormat)
+		return 2
+	}
+	logger := slog.New(handler)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(signals)
+
+	c := newCron(logger, stdout, stderr)
+	if err := c.reload(*path); err != nil {
+		logger.Error("failed to load the schedule", "error", err)
+		return 1
+	}
+	for sig := range signals {
+		if sig == syscall.SIGHUP {
+			if err := c.reload(*path); err != nil {
+				logger.Error("failed to reload the schedule", "error", err)
+			} else {
+				logger.Info("schedule reloaded")
+			}
+			continue
+		}
+		logger.Info("draining", "signal", sig.String())
+		if !c.drain(*drainTimeout) {
+			logger.Error("drain timeout exceeded")
+			return 1
+		}
+		return 0
+	}
+	return 0
+}
+
+// cron runs the commands of the schedule.
+type cron struct {
+	scheduler      *scheduler.Scheduler
+	logger         *slog.Logger
+	stdout, stderr io.Writer
+
+	mux sync.Mutex
+	// commands are looked up on every run, so that the reloaded commands are
+	// applied without restarting the tasks.
+	commands map[string]command
+
+	// killed is cancelled to kill the running commands.
+	killed context.Context
+	kill   context.CancelFunc
+}
+
+// command is the shell command of a task.
+type command struct {
+	line string
+	// concurrent commands may overlap, up to the task concurrency.
+	concurrent bool
+}
+
+// killTimeout is the time to wait for the killed commands to finish.
+const killTimeout = 2 * time.Second
+
+func newCron(logger *slog.Logger, stdout, stderr io.Writer) *cron {
+	killed, kill := context.WithCancel(context.Background())
+	return &cron{
+		scheduler: scheduler.New(scheduler.WithLogger(logger)),
+		logger:    logger,
+		stdout:    stdout,
+		stderr:    stderr,
+		commands:  make(map[string]command),
+		killed:    killed,
+		kill:      kill,
+	}
+}
+
+func (c *cron) reload(path string) error {
+	s, err := loadSchedule(path)
+	if err != nil {
+		return err
+	}
+	c.mux.Lock()
+	for name, line := range s.commands {
+		if _, ok := c.commands[name]; !ok {
+			c.scheduler.Register(name, c.task(name))
+		}
+		c.commands[name] = command{line, s.config.Tasks[name].Concurrency > 0}
+	}
+	c.mux.Unlock()
+	return c.scheduler.Apply(s.config)
+}
+
+// task returns the function, that runs the current command of the task.
+// Unless the command is concurrent, the runs do not overlap: a run is skipped
+// while the previous one is running.
+func (c *cron) task(name string) func(context.Context, time.Time) error {
+	var running atomic.Int32
+	return func(ctx context.Context, tick time.Time) error {
+		c.mux.Lock()
+		command := c.commands[name]
+		c.mux.Unlock()
+		if running.Add(1) > 1 && !command.concurrent {
+			running.Add(-1)
+			c.logger.Warn("command run skipped", "task", name, "reason", "previous run is not finished")
+			return nil
+		}
+		defer running.Add(-1)
+		return c.exec(ctx, name, command.line, tick)
+	}
+}
+
+// exec runs the command with the tick in the [utils.DefaultTickEnv]
+// environment variable. The command process group is killed on kill.
+func (c *cron) exec(ctx context.Context, name, command string, tick time.Time) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(c.killed, cancel)()
+	logger := c.logger.With("task", name)
+	if attempt, _ := ctx.Value(utils.AttemptNumber).(int); attempt > 0 {
+		logger = logger.With("retry", attempt)
+	}
+	logger.Info("command started", "command", command)
+	start := time.Now()
+	err := utils.Shell[time.Time](command, utils.WithOutput(c.stdout, c.stderr))(ctx, tick)
+	if err == nil {
+		logger.Info("command finished", "duration", time.Since(start))
+	}
+	return err
+}
+
+// drain stops the scheduling and waits for the running commands up to the
+// timeout, after which the commands are killed. It reports whether the
+// commands have finished in time.
+func (c *cron) drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		c.scheduler.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+	}
+	c.kill()
+	select {
+	case <-done:
+	case <-time.After(killTimeout):
+		c.logger.Error("killed commands have not finished")
+	}
+	return false
+}
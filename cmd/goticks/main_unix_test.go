@@ -0,0 +1,97 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+// syncBuffer is a bytes.Buffer, safe for concurrent writes.
+type syncBuffer struct {
+	mux sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.String()
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goticks.conf")
+	_ = os.WriteFile(path, []byte("hello period=1h -- echo hello\n"), 0o600)
+	var stdout, stderr syncBuffer
+	code := make(chan int)
+	go func() {
+		code <- run([]string{"-schedule", path}, &stdout, &stderr)
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	_ = os.WriteFile(path, []byte("hello period=1h -- echo reloaded\nslow period=1h -- sleep 0.3; echo drained\n"), 0o600)
+	_ = syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	time.Sleep(200 * time.Millisecond)
+	_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+
+	assert.That(t,
+		assert.Equal(0, <-code),
+		assert.Equal("hello\ndrained\n", stdout.String()),
+		assert.True(strings.Contains(stderr.String(), "schedule reloaded")),
+		assert.True(strings.Contains(stderr.String(), "draining")))
+}
+
+func TestRun_concurrency(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goticks.conf")
+	_ = os.WriteFile(path, []byte("poll period=50ms concurrency=3 -- echo run; sleep 0.3\n"), 0o600)
+	var stdout, stderr syncBuffer
+	code := make(chan int)
+	go func() {
+		code <- run([]string{"-schedule", path}, &stdout, &stderr)
+	}()
+	time.Sleep(200 * time.Millisecond)
+	_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+
+	assert.That(t,
+		assert.Equal(0, <-code),
+		assert.True(strings.Count(stdout.String(), "run\n") > 1))
+}
+
+func TestRun_drainTimeout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "goticks.conf")
+	late := filepath.Join(dir, "late")
+	// The background command writes the file, unless the process group is
+	// killed.
+	_ = os.WriteFile(path, []byte("slow period=1h -- (sleep 0.5; touch "+late+") & sleep 5\n"), 0o600)
+	var stdout, stderr syncBuffer
+	code := make(chan int)
+	go func() {
+		code <- run([]string{"-schedule", path, "-drain-timeout", "100ms"}, &stdout, &stderr)
+	}()
+	time.Sleep(200 * time.Millisecond)
+	start := time.Now()
+	_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+
+	assert.That(t,
+		assert.Equal(1, <-code),
+		assert.True(time.Since(start) < time.Second),
+		assert.True(strings.Contains(stderr.String(), "drain timeout exceeded")))
+	time.Sleep(600 * time.Millisecond)
+	_, err := os.Stat(late)
+	assert.That(t, assert.True(os.IsNotExist(err)))
+}
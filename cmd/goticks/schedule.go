@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/parametalol/goticks/scheduler"
+)
+
+// schedule is the parsed schedule file.
+type schedule struct {
+	config   scheduler.Config
+	commands map[string]string
+}
+
+// parseSchedule parses the schedule file lines, each with the task name, the
+// [scheduler.ParseText] settings and the shell command after " -- ":
+//
+//	# name  settings                             -- command
+//	backup  period=1h timeout=10m retries=2      -- tar czf /backup/data.tgz /data
+//	report  cron="0 9 * * mon-fri" jitter=1m     -- /usr/local/bin/report --daily
+//	poll    period=10s concurrency=3             -- /usr/local/bin/poll
+//
+// The runs of a command overlap only with the concurrency setting.
+func parseSchedule(r io.Reader) (schedule, error) {
+	commands := make(map[string]string)
+	var settings strings.Builder
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			left, command, ok := strings.Cut(line, " -- ")
+			if command = strings.TrimSpace(command); !ok || command == "" {
+				return schedule{}, fmt.Errorf("line %d: missing command after \" -- \"", n)
+			}
+			commands[strings.Fields(left)[0]] = command
+			line = left
+		}
+		// Keep the line numbers for the settings errors.
+		settings.WriteString(line)
+		settings.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return schedule{}, err
+	}
+	config, err := scheduler.ParseText(strings.NewReader(settings.String()))
+	if err != nil {
+		return schedule{}, err
+	}
+	return schedule{config, commands}, nil
+}
+
+func loadSchedule(path string) (schedule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return schedule{}, err
+	}
+	defer func() { _ = f.Close() }()
+	s, err := parseSchedule(f)
+	if err != nil {
+		return schedule{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/scheduler"
+)
+
+func TestParseSchedule(t *testing.T) {
+	s, err := parseSchedule(strings.NewReader(`
+		# name  settings                        -- command
+		backup  period=1h timeout=10m retries=2 -- tar czf /backup/data.tgz /data
+		report  cron="0 9 * * mon-fri"          -- echo "a -- b"
+	`))
+	assert.That(t,
+		assert.NoError(err),
+		assert.Equal(2, len(s.config.Tasks)),
+		assert.Equal(scheduler.TaskConfig{
+			Period:  scheduler.Duration(time.Hour),
+			Timeout: scheduler.Duration(10 * time.Minute),
+			Retries: 2,
+			Enabled: true,
+		}, s.config.Tasks["backup"]),
+		assert.Equal("0 9 * * mon-fri", s.config.Tasks["report"].Cron),
+		assert.Equal("tar czf /backup/data.tgz /data", s.commands["backup"]),
+		assert.Equal(`echo "a -- b"`, s.commands["report"]))
+
+	_, errCommand := parseSchedule(strings.NewReader("\nbackup period=1h"))
+	_, errSettings := parseSchedule(strings.NewReader("\n\nbackup perod=1h -- true"))
+	assert.That(t,
+		assert.Equal(`line 2: missing command after " -- "`, errCommand.Error()),
+		assert.Equal("line 3: perod: unknown setting", errSettings.Error()))
+}
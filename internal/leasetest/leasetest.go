@@ -0,0 +1,28 @@
+// Package leasetest provides the lease lockers for testing the lease handling
+// within the module.
+package leasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/parametalol/goticks/lease"
+)
+
+type lostLocker struct{}
+
+var _ lease.Locker = lostLocker{}
+var _ lease.Lease = lostLocker{}
+
+// Lost returns a locker, that grants the leases, which cannot be renewed:
+// [lease.Lease.Renew] fails with [lease.ErrLost].
+func Lost() lease.Locker {
+	return lostLocker{}
+}
+
+func (lostLocker) TryAcquire(context.Context, time.Duration) (lease.Lease, error) {
+	return lostLocker{}, nil
+}
+
+func (lostLocker) Renew(context.Context) error { return lease.ErrLost }
+func (lostLocker) Release() error              { return nil }
@@ -0,0 +1,99 @@
+package goticks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/parametalol/goticks/lease"
+)
+
+// leaseKeeper holds the lease across the task executions, renewing it in
+// background.
+type leaseKeeper struct {
+	locker lease.Locker
+	ttl    time.Duration
+
+	mux    sync.Mutex
+	lease  lease.Lease
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+// acquire returns the context of the held lease, acquiring the lease if
+// necessary. The context is cancelled when the lease is lost or released.
+func (k *leaseKeeper) acquire(ctx context.Context) (context.Context, error) {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	if k.lease != nil {
+		return k.ctx, nil
+	}
+	l, err := k.locker.TryAcquire(ctx, k.ttl)
+	if err != nil {
+		return nil, err
+	}
+	k.lease = l
+	k.ctx, k.cancel = context.WithCancelCause(context.Background())
+	if k.ttl/3 > 0 {
+		go k.renew(l, k.ctx)
+	}
+	return k.ctx, nil
+}
+
+func (k *leaseKeeper) renew(l lease.Lease, ctx context.Context) {
+	renew := time.NewTicker(k.ttl / 3)
+	defer renew.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-renew.C:
+			if err := l.Renew(ctx); err != nil {
+				k.drop(l, lease.ErrLost)
+				return
+			}
+		}
+	}
+}
+
+// drop releases the lease, if it is still held, and cancels its context with
+// the cause.
+func (k *leaseKeeper) drop(l lease.Lease, cause error) {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	if k.lease == nil || (l != nil && k.lease != l) {
+		return
+	}
+	k.cancel(cause)
+	_ = k.lease.Release()
+	k.lease = nil
+}
+
+// release releases the held lease.
+func (k *leaseKeeper) release() {
+	k.drop(nil, context.Canceled)
+}
+
+// withLease wraps the task, so that it only runs while holding the lease.
+func withLease[TickType any](keeper *leaseKeeper, task func(context.Context, TickType) error) func(context.Context, TickType) error {
+	if keeper == nil {
+		return task
+	}
+	return func(ctx context.Context, tick TickType) error {
+		leaseCtx, err := keeper.acquire(ctx)
+		if errors.Is(err, lease.ErrNotAcquired) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		stop := context.AfterFunc(leaseCtx, func() {
+			cancel(context.Cause(leaseCtx))
+		})
+		defer stop()
+		return task(ctx, tick)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build !unix
+
+package lease
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type fileLocker struct{}
+
+// NewFile returns a locker, that grants the lease by holding an exclusive
+// flock on the file. It is not supported on this platform, and
+// [Locker.TryAcquire] returns [errors.ErrUnsupported].
+func NewFile(string) Locker {
+	return fileLocker{}
+}
+
+func (fileLocker) TryAcquire(context.Context, time.Duration) (Lease, error) {
+	return nil, errors.ErrUnsupported
+}
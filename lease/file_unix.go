@@ -0,0 +1,69 @@
+//go:build unix
+
+package lease
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type fileLocker struct {
+	path string
+}
+
+type fileLease struct {
+	mux  sync.Mutex
+	file *os.File
+}
+
+var _ Locker = (*fileLocker)(nil)
+var _ Lease = (*fileLease)(nil)
+
+// NewFile returns a locker, that grants the lease by holding an exclusive
+// flock on the file, creating it if necessary. The lock is released by the
+// system when the process exits, so the TTL is not used, and the lease is
+// only exclusive among the processes sharing the file system.
+func NewFile(path string) Locker {
+	return &fileLocker{path: path}
+}
+
+func (l *fileLocker) TryAcquire(context.Context, time.Duration) (Lease, error) {
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, ErrNotAcquired
+		}
+		return nil, err
+	}
+	return &fileLease{file: f}, nil
+}
+
+func (l *fileLease) Renew(context.Context) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.file == nil {
+		return ErrLost
+	}
+	return nil
+}
+
+func (l *fileLease) Release() error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := errors.Join(
+		syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN),
+		l.file.Close())
+	l.file = nil
+	return err
+}
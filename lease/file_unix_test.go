@@ -0,0 +1,32 @@
+//go:build unix
+
+package lease
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "lock")
+
+	l1, err := NewFile(path).TryAcquire(ctx, time.Minute)
+	assert.That(t, assert.NoError(err))
+
+	_, err = NewFile(path).TryAcquire(ctx, time.Minute)
+	assert.That(t,
+		assert.ErrorIs(err, ErrNotAcquired),
+		assert.NoError(l1.Renew(ctx)),
+		assert.NoError(l1.Release()),
+		assert.ErrorIs(l1.Renew(ctx), ErrLost))
+
+	l2, err := NewFile(path).TryAcquire(ctx, time.Minute)
+	assert.That(t,
+		assert.NoError(err),
+		assert.NoError(l2.Release()))
+}
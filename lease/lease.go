@@ -0,0 +1,30 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotAcquired is returned by [Locker.TryAcquire] when the lease is held by
+// another owner.
+var ErrNotAcquired = errors.New("lease not acquired")
+
+// ErrLost is returned by [Lease.Renew] when the lease has expired and may have
+// been acquired by another owner.
+var ErrLost = errors.New("lease lost")
+
+// Lease is an acquired exclusive right to run, valid for a TTL unless renewed.
+type Lease interface {
+	// Renew extends the lease for another TTL.
+	Renew(ctx context.Context) error
+	// Release gives up the lease.
+	Release() error
+}
+
+// Locker grants the leases.
+type Locker interface {
+	// TryAcquire acquires the lease for the ttl without waiting. It returns
+	// [ErrNotAcquired] if the lease is held by another owner.
+	TryAcquire(ctx context.Context, ttl time.Duration) (Lease, error)
+}
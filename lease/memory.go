@@ -0,0 +1,60 @@
+package lease
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryLocker struct {
+	mux     sync.Mutex
+	holder  *memoryLease
+	expires time.Time
+}
+
+type memoryLease struct {
+	locker *memoryLocker
+	ttl    time.Duration
+}
+
+var _ Locker = (*memoryLocker)(nil)
+var _ Lease = (*memoryLease)(nil)
+
+// NewMemory returns a locker, that grants the leases within the process.
+// It is mainly useful for testing, with one locker shared by the simulated
+// instances.
+func NewMemory() Locker {
+	return &memoryLocker{}
+}
+
+func (l *memoryLocker) TryAcquire(_ context.Context, ttl time.Duration) (Lease, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	now := time.Now()
+	if l.holder != nil && now.Before(l.expires) {
+		return nil, ErrNotAcquired
+	}
+	l.holder = &memoryLease{locker: l, ttl: ttl}
+	l.expires = now.Add(ttl)
+	return l.holder, nil
+}
+
+func (l *memoryLease) Renew(context.Context) error {
+	l.locker.mux.Lock()
+	defer l.locker.mux.Unlock()
+	now := time.Now()
+	if l.locker.holder != l || !now.Before(l.locker.expires) {
+		return ErrLost
+	}
+	l.locker.expires = now.Add(l.ttl)
+	return nil
+}
+
+func (l *memoryLease) Release() error {
+	l.locker.mux.Lock()
+	defer l.locker.mux.Unlock()
+	if l.locker.holder == l {
+		l.locker.holder = nil
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package lease
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	locker := NewMemory()
+
+	l1, err := locker.TryAcquire(ctx, 50*time.Millisecond)
+	assert.That(t, assert.NoError(err))
+
+	_, err = locker.TryAcquire(ctx, time.Minute)
+	assert.That(t,
+		assert.ErrorIs(err, ErrNotAcquired),
+		assert.NoError(l1.Renew(ctx)))
+
+	time.Sleep(60 * time.Millisecond)
+	l2, err := locker.TryAcquire(ctx, time.Minute)
+	assert.That(t,
+		assert.NoError(err),
+		assert.ErrorIs(l1.Renew(ctx), ErrLost),
+		assert.NoError(l1.Release()))
+
+	_, err = locker.TryAcquire(ctx, time.Minute)
+	assert.That(t,
+		assert.ErrorIs(err, ErrNotAcquired),
+		assert.NoError(l2.Release()))
+
+	_, err = locker.TryAcquire(ctx, time.Minute)
+	assert.That(t, assert.NoError(err))
+}
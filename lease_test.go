@@ -0,0 +1,65 @@
+package goticks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/internal/leasetest"
+	"github.com/parametalol/goticks/lease"
+	"github.com/parametalol/goticks/ticker"
+)
+
+func TestWithLease(t *testing.T) {
+	t.Run("single instance", func(t *testing.T) {
+		locker := lease.NewMemory()
+		ticker := ticker.New[int]()
+
+		var ticks [2][]int
+		var tasks [2]Task
+		for i := range tasks {
+			tasks[i] = NewTask(ticker, func(tick int) {
+				ticks[i] = append(ticks[i], tick)
+			}, WithLease(locker, time.Minute))
+		}
+		tasks[0].Start()
+		ticker.Tick(1).Wait()
+		tasks[1].Start()
+		ticker.Tick(2).Wait()
+		tasks[0].Stop()
+		ticker.Tick(3).Wait()
+		tasks[1].Stop()
+
+		assert.That(t,
+			assert.EqualSlices([]int{1, 2}, ticks[0]),
+			assert.EqualSlices([]int{3}, ticks[1]))
+	})
+
+	t.Run("zero ttl", func(t *testing.T) {
+		ticker := ticker.New[int]()
+		var ticks []int
+		task := NewTask(ticker, func(tick int) {
+			time.Sleep(10 * time.Millisecond)
+			ticks = append(ticks, tick)
+		}, WithLease(lease.NewMemory(), 0))
+		task.Start()
+		ticker.Tick(1).Wait()
+		ticker.Tick(2).Wait()
+		task.Stop()
+		assert.That(t, assert.EqualSlices([]int{1, 2}, ticks))
+	})
+
+	t.Run("lost lease", func(t *testing.T) {
+		ticker := ticker.New[int]()
+		var cause error
+		task := NewTask(ticker, func(ctx context.Context) {
+			<-ctx.Done()
+			cause = context.Cause(ctx)
+		}, WithLease(leasetest.Lost(), 30*time.Millisecond))
+		task.Start()
+		ticker.Tick(1).Wait()
+		task.Stop()
+		assert.That(t, assert.ErrorIs(cause, lease.ErrLost))
+	})
+}
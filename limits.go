@@ -0,0 +1,85 @@
+package goticks
+
+import (
+	"context"
+	"time"
+)
+
+// withWindow wraps the task, so that it only runs within the validity window,
+// and stops the task when the window closes.
+func (t *taskImpl[TickType]) withWindow(task func(context.Context, TickType) error) func(context.Context, TickType) error {
+	o := &t.options
+	if o.notBefore.IsZero() && o.notAfter.IsZero() {
+		return task
+	}
+	return func(ctx context.Context, tick TickType) error {
+		if t.expired.Load() {
+			return nil
+		}
+		now := time.Now()
+		if !o.notBefore.IsZero() && now.Before(o.notBefore) {
+			return nil
+		}
+		if !o.notAfter.IsZero() && !now.Before(o.notAfter) {
+			t.expire()
+			return nil
+		}
+		return task(ctx, tick)
+	}
+}
+
+// withRunLimits wraps the task, so that it stops the task when the maximum
+// number of runs is reached. It is applied inside the lease and the hooks, so
+// that only the actual executions are counted.
+func (t *taskImpl[TickType]) withRunLimits(task func(context.Context, TickType) error) func(context.Context, TickType) error {
+	o := &t.options
+	if o.maxRuns == 0 && o.maxAttempts == 0 {
+		return task
+	}
+	return func(ctx context.Context, tick TickType) error {
+		if t.expired.Load() {
+			return nil
+		}
+		err := task(ctx, tick)
+		attempts := t.attempts.Add(1)
+		runs := t.runs.Load()
+		if err == nil {
+			runs = t.runs.Add(1)
+		}
+		if (o.maxAttempts > 0 && attempts >= o.maxAttempts) || (o.maxRuns > 0 && runs >= o.maxRuns) {
+			t.expire()
+		}
+		return err
+	}
+}
+
+// startLimits resets the run counters, and schedules the task stop at the end
+// of the validity window.
+func (t *taskImpl[TickType]) startLimits() {
+	t.expired.Store(false)
+	t.runs.Store(0)
+	t.attempts.Store(0)
+	if !t.options.notAfter.IsZero() {
+		t.mux.Lock()
+		t.notAfterTimer = time.AfterFunc(time.Until(t.options.notAfter), t.expire)
+		t.mux.Unlock()
+	}
+}
+
+func (t *taskImpl[TickType]) stopLimits() {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	if t.notAfterTimer != nil {
+		t.notAfterTimer.Stop()
+		t.notAfterTimer = nil
+	}
+}
+
+// expire stops the task once. The task is stopped in a goroutine, as expire
+// may be called by the task loop, and stopping the ticker may wait for the
+// current tick to be processed.
+func (t *taskImpl[TickType]) expire() {
+	if !t.expired.Swap(true) {
+		go t.Stop()
+	}
+}
@@ -0,0 +1,104 @@
+package goticks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/lease"
+	"github.com/parametalol/goticks/ticker"
+)
+
+func TestLimits(t *testing.T) {
+	t.Run("max runs", func(t *testing.T) {
+		ticker := ticker.New[int]()
+		stopped := make(chan struct{})
+		var ticks []int
+		task := NewTask(ticker, func(tick int) error {
+			ticks = append(ticks, tick)
+			if tick%2 == 1 {
+				return errors.New("odd")
+			}
+			return nil
+		}, WithMaxRuns(2), WithTickerStop(), WithOnStop(func() { close(stopped) }))
+		task.Start()
+		for i := range 5 {
+			ticker.Tick(i).Wait()
+		}
+		<-stopped
+		assert.That(t,
+			assert.EqualSlices([]int{0, 1, 2}, ticks))
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		ticker := ticker.New[int]()
+		stopped := make(chan struct{})
+		var ticks []int
+		task := NewTask(ticker, func(tick int) error {
+			ticks = append(ticks, tick)
+			return errors.New("test")
+		}, WithMaxAttempts(2), WithOnStop(func() { close(stopped) }))
+		task.Start()
+		for i := range 5 {
+			ticker.Tick(i).Wait()
+		}
+		<-stopped
+		assert.That(t,
+			assert.EqualSlices([]int{0, 1}, ticks))
+	})
+
+	t.Run("skipped ticks", func(t *testing.T) {
+		locker := lease.NewMemory()
+		held, _ := locker.TryAcquire(context.Background(), time.Minute)
+		ticker := ticker.New[int]()
+		stopped := make(chan struct{})
+		var ticks []int
+		task := NewTask(ticker, func(tick int) {
+			ticks = append(ticks, tick)
+		},
+			WithLease(locker, time.Minute),
+			WithBeforeRun(func(_ context.Context, tick int) (context.Context, error) {
+				if tick == 2 {
+					return nil, errors.New("skip")
+				}
+				return context.Background(), nil
+			}),
+			WithMaxRuns(2), WithOnStop(func() { close(stopped) }))
+		task.Start()
+		// The lease is held by another instance.
+		ticker.Tick(0).Wait()
+		ticker.Tick(1).Wait()
+		_ = held.Release()
+		for i := 2; i < 6; i++ {
+			ticker.Tick(i).Wait()
+		}
+		<-stopped
+		assert.That(t,
+			assert.EqualSlices([]int{3, 4}, ticks))
+	})
+
+	t.Run("window", func(t *testing.T) {
+		ticker := ticker.New[int]()
+		stopped := make(chan struct{})
+		var ticks []int
+		start := time.Now()
+		task := NewTask(ticker, func(tick int) {
+			ticks = append(ticks, tick)
+		},
+			WithNotBefore(start.Add(50*time.Millisecond)),
+			WithNotAfter(start.Add(150*time.Millisecond)),
+			WithOnStop(func() { close(stopped) }))
+		task.Start()
+		ticker.Tick(1).Wait()
+		time.Sleep(100 * time.Millisecond)
+		ticker.Tick(2).Wait()
+		// The task is stopped at the end of the window without ticks.
+		<-stopped
+		ticker.Tick(3).Wait()
+		assert.That(t,
+			assert.EqualSlices([]int{2}, ticks),
+			assert.True(time.Since(start) >= 150*time.Millisecond))
+	})
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/parametalol/goticks/ticker"
+)
+
+// Expvar is a [Recorder], that publishes the metrics with the [expvar]
+// package.
+//
+// The published variable is a map with the following keys:
+//
+//	runs, failures, retries, skipped, in_flight: task name to integer;
+//	duration, tick_lag: task name to {"count", "sum"} (in seconds);
+//	consumers: ticker name to integer.
+type Expvar struct {
+	root      *expvar.Map
+	runs      *expvar.Map
+	failures  *expvar.Map
+	retries   *expvar.Map
+	skipped   *expvar.Map
+	inFlight  *expvar.Map
+	durations *expvar.Map
+	lags      *expvar.Map
+	consumers *expvar.Map
+
+	mux sync.Mutex
+}
+
+var _ Recorder = (*Expvar)(nil)
+
+// NewExpvar publishes the metrics map under the given name.
+// As [expvar.Publish], it panics if the name is already registered.
+func NewExpvar(name string) *Expvar {
+	e := &Expvar{
+		root:      expvar.NewMap(name),
+		runs:      new(expvar.Map),
+		failures:  new(expvar.Map),
+		retries:   new(expvar.Map),
+		skipped:   new(expvar.Map),
+		inFlight:  new(expvar.Map),
+		durations: new(expvar.Map),
+		lags:      new(expvar.Map),
+		consumers: new(expvar.Map),
+	}
+	e.root.Set("runs", e.runs)
+	e.root.Set("failures", e.failures)
+	e.root.Set("retries", e.retries)
+	e.root.Set("skipped", e.skipped)
+	e.root.Set("in_flight", e.inFlight)
+	e.root.Set("duration", e.durations)
+	e.root.Set("tick_lag", e.lags)
+	e.root.Set("consumers", e.consumers)
+	return e
+}
+
+// Map returns the published variable.
+func (e *Expvar) Map() *expvar.Map {
+	return e.root
+}
+
+func (e *Expvar) AddRun(task string)     { e.runs.Add(task, 1) }
+func (e *Expvar) AddFailure(task string) { e.failures.Add(task, 1) }
+func (e *Expvar) AddRetry(task string)   { e.retries.Add(task, 1) }
+func (e *Expvar) AddSkipped(task string) { e.skipped.Add(task, 1) }
+
+func (e *Expvar) AddInFlight(task string, delta int) {
+	e.inFlight.Add(task, int64(delta))
+}
+
+func (e *Expvar) observe(m *expvar.Map, task string, d time.Duration) {
+	e.mux.Lock()
+	h, ok := m.Get(task).(*expvar.Map)
+	if !ok {
+		h = new(expvar.Map).Init()
+		h.Set("count", new(expvar.Int))
+		h.Set("sum", new(expvar.Float))
+		m.Set(task, h)
+	}
+	e.mux.Unlock()
+	h.Add("count", 1)
+	h.AddFloat("sum", d.Seconds())
+}
+
+func (e *Expvar) ObserveDuration(task string, d time.Duration) {
+	e.observe(e.durations, task, d)
+}
+
+func (e *Expvar) ObserveTickLag(task string, d time.Duration) {
+	e.observe(e.lags, task, d)
+}
+
+func (e *Expvar) ObserveConsumers(ticker string, t ticker.Observable) {
+	e.consumers.Set(ticker, expvar.Func(func() any {
+		return t.Consumers()
+	}))
+}
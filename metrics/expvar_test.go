@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/ticker"
+)
+
+// expvarID makes the published names unique across the test runs.
+var expvarID atomic.Int32
+
+func TestExpvar(t *testing.T) {
+	e := NewExpvar(fmt.Sprint("goticks_test_", expvarID.Add(1)))
+	_ = Record[time.Time](e, "test", func() error {
+		return errors.New("test")
+	})(context.Background(), time.Now())
+	e.AddSkipped("test")
+
+	tt := ticker.New[int]()
+	_ = tt.Ticks()
+	_ = tt.Ticks()
+	e.ObserveConsumers("ticker", tt.(ticker.Observable))
+
+	m := e.Map()
+	assert.That(t,
+		assert.Equal(`{"test": 1}`, m.Get("runs").String()),
+		assert.Equal(`{"test": 1}`, m.Get("failures").String()),
+		assert.Equal(`{}`, m.Get("retries").String()),
+		assert.Equal(`{"test": 1}`, m.Get("skipped").String()),
+		assert.Equal(`{"test": 0}`, m.Get("in_flight").String()),
+		assert.Equal(`{"ticker": 2}`, m.Get("consumers").String()),
+		assert.Equal("1", m.Get("duration").(*expvar.Map).
+			Get("test").(*expvar.Map).Get("count").String()))
+}
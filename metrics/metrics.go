@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+// Recorder collects the task and ticker metrics.
+// The implementations must be safe for concurrent use.
+type Recorder interface {
+	// AddRun counts a task execution, including retries.
+	AddRun(task string)
+	// AddFailure counts a task execution, that returned an error.
+	AddFailure(task string)
+	// AddRetry counts a task retry attempt.
+	AddRetry(task string)
+	// AddSkipped counts a task execution, skipped due to an overlap.
+	AddSkipped(task string)
+	// AddInFlight changes the number of the currently running task executions.
+	AddInFlight(task string, delta int)
+	// ObserveDuration records the duration of a task execution.
+	ObserveDuration(task string, d time.Duration)
+	// ObserveTickLag records the delay between a time tick and the beginning
+	// of the task execution.
+	ObserveTickLag(task string, d time.Duration)
+	// ObserveConsumers registers a ticker, which number of consumers is
+	// reported on every collection.
+	ObserveConsumers(ticker string, t ticker.Observable)
+}
+
+type taskRanCtxKey struct{}
+
+// Record adds metrics collection to the task.
+// It counts the runs and the failures, the number of concurrent executions,
+// and records the execution duration. For the [time.Time] ticks it also
+// records the lag between the tick and the execution start.
+//
+// Wrap the task in [utils.Retry] to count the retries:
+//
+//	utils.Retry[int](policy, metrics.Record[int](rec, "name", task))
+func Record[TickType any, Fn utils.Func[TickType]](rec Recorder, name string, task Fn) func(context.Context, TickType) error {
+	adaptedTask := utils.Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		if attempt, _ := ctx.Value(utils.AttemptNumber).(int); attempt > 0 {
+			rec.AddRetry(name)
+		} else if t, ok := any(tick).(time.Time); ok {
+			rec.ObserveTickLag(name, time.Since(t))
+		}
+		rec.AddRun(name)
+		rec.AddInFlight(name, 1)
+		start := time.Now()
+		err := adaptedTask(ctx, tick)
+		rec.ObserveDuration(name, time.Since(start))
+		rec.AddInFlight(name, -1)
+		if err != nil {
+			rec.AddFailure(name)
+		}
+		return err
+	}
+}
+
+// NoOverlap is the [utils.NoOverlap] wrapper, that counts the skipped
+// executions.
+func NoOverlap[TickType any, Fn utils.Func[TickType]](rec Recorder, name string, task Fn) func(context.Context, TickType) error {
+	adaptedTask := utils.Adapt[TickType](task)
+	noOverlapTask := utils.NoOverlap[TickType](func(ctx context.Context, tick TickType) error {
+		*ctx.Value(taskRanCtxKey{}).(*bool) = true
+		return adaptedTask(ctx, tick)
+	})
+	return func(ctx context.Context, tick TickType) error {
+		var ran bool
+		err := noOverlapTask(context.WithValue(ctx, taskRanCtxKey{}, &ran), tick)
+		if !ran {
+			rec.AddSkipped(name)
+		}
+		return err
+	}
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+type testRecorder struct {
+	mux      sync.Mutex
+	events   []string
+	inFlight int
+	lags     int
+}
+
+func (r *testRecorder) add(event string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *testRecorder) AddRun(task string)     { r.add("run " + task) }
+func (r *testRecorder) AddFailure(task string) { r.add("failure " + task) }
+func (r *testRecorder) AddRetry(task string)   { r.add("retry " + task) }
+func (r *testRecorder) AddSkipped(task string) { r.add("skipped " + task) }
+func (r *testRecorder) AddInFlight(_ string, delta int) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.inFlight += delta
+}
+func (r *testRecorder) ObserveDuration(task string, _ time.Duration) { r.add("duration " + task) }
+func (r *testRecorder) ObserveTickLag(string, time.Duration) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.lags++
+}
+func (r *testRecorder) ObserveConsumers(string, ticker.Observable) {}
+
+func TestRecord(t *testing.T) {
+	t.Run("runs and retries", func(t *testing.T) {
+		rec := &testRecorder{}
+		err := utils.Retry[int](utils.SimpleRetryPolicy(2),
+			Record[int](rec, "test", func() error {
+				return errors.New("test")
+			}))(context.Background(), 0)
+		assert.That(t,
+			assert.Not(assert.NoError(err)),
+			assert.EqualSlices([]string{
+				"run test", "duration test", "failure test",
+				"retry test", "run test", "duration test", "failure test",
+			}, rec.events),
+			assert.Equal(0, rec.inFlight),
+			assert.Equal(0, rec.lags))
+	})
+
+	t.Run("tick lag", func(t *testing.T) {
+		rec := &testRecorder{}
+		err := Record[time.Time](rec, "test", func() {})(context.Background(), time.Now())
+		assert.That(t,
+			assert.NoError(err),
+			assert.EqualSlices([]string{"run test", "duration test"}, rec.events),
+			assert.Equal(1, rec.lags))
+	})
+}
+
+func TestNoOverlap(t *testing.T) {
+	rec := &testRecorder{}
+	testCh := make(chan bool)
+	fn := NoOverlap[any](rec, "test", func() {
+		testCh <- true
+		testCh <- true
+	})
+	go func() {
+		_ = fn(context.Background(), 0)
+	}()
+	<-testCh
+	_ = fn(context.Background(), 0)
+	_ = fn(context.Background(), 0)
+	<-testCh
+	assert.That(t,
+		assert.EqualSlices([]string{"skipped test", "skipped test"}, rec.events))
+}
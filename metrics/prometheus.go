@@ -0,0 +1,194 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parametalol/goticks/ticker"
+)
+
+// DefaultBuckets are the histogram buckets in seconds, used by [NewPrometheus].
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(buckets []float64, v float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(buckets))
+	}
+	for i, le := range buckets {
+		if v <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+// Prometheus is a [Recorder], that serves the collected metrics over HTTP in
+// the Prometheus text exposition format.
+type Prometheus struct {
+	buckets []float64
+
+	mux       sync.Mutex
+	runs      map[string]uint64
+	failures  map[string]uint64
+	retries   map[string]uint64
+	skipped   map[string]uint64
+	inFlight  map[string]int64
+	durations map[string]*histogram
+	lags      map[string]*histogram
+	consumers map[string]ticker.Observable
+}
+
+var _ Recorder = (*Prometheus)(nil)
+var _ http.Handler = (*Prometheus)(nil)
+
+// NewPrometheus returns a recorder with the histogram buckets in seconds.
+// If no buckets are provided, [DefaultBuckets] are used.
+func NewPrometheus(buckets ...float64) *Prometheus {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &Prometheus{
+		buckets:   buckets,
+		runs:      make(map[string]uint64),
+		failures:  make(map[string]uint64),
+		retries:   make(map[string]uint64),
+		skipped:   make(map[string]uint64),
+		inFlight:  make(map[string]int64),
+		durations: make(map[string]*histogram),
+		lags:      make(map[string]*histogram),
+		consumers: make(map[string]ticker.Observable),
+	}
+}
+
+func (p *Prometheus) add(m map[string]uint64, task string) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	m[task]++
+}
+
+func (p *Prometheus) observe(m map[string]*histogram, task string, d time.Duration) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	h, ok := m[task]
+	if !ok {
+		h = &histogram{}
+		m[task] = h
+	}
+	h.observe(p.buckets, d.Seconds())
+}
+
+func (p *Prometheus) AddRun(task string)     { p.add(p.runs, task) }
+func (p *Prometheus) AddFailure(task string) { p.add(p.failures, task) }
+func (p *Prometheus) AddRetry(task string)   { p.add(p.retries, task) }
+func (p *Prometheus) AddSkipped(task string) { p.add(p.skipped, task) }
+
+func (p *Prometheus) AddInFlight(task string, delta int) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.inFlight[task] += int64(delta)
+}
+
+func (p *Prometheus) ObserveDuration(task string, d time.Duration) {
+	p.observe(p.durations, task, d)
+}
+
+func (p *Prometheus) ObserveTickLag(task string, d time.Duration) {
+	p.observe(p.lags, task, d)
+}
+
+func (p *Prometheus) ObserveConsumers(ticker string, t ticker.Observable) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.consumers[ticker] = t
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = p.Write(w)
+}
+
+// Write writes the metrics in the Prometheus text exposition format.
+func (p *Prometheus) Write(w io.Writer) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	var b strings.Builder
+	writeCounter(&b, "goticks_task_runs_total", "Number of task executions.", p.runs)
+	writeCounter(&b, "goticks_task_failures_total", "Number of failed task executions.", p.failures)
+	writeCounter(&b, "goticks_task_retries_total", "Number of task retries.", p.retries)
+	writeCounter(&b, "goticks_task_skipped_total", "Number of task executions skipped due to an overlap.", p.skipped)
+	writeGauge(&b, "goticks_task_in_flight", "Number of running task executions.", "task", p.inFlight)
+	p.writeHistogram(&b, "goticks_task_duration_seconds", "Task execution duration.", p.durations)
+	p.writeHistogram(&b, "goticks_task_tick_lag_seconds", "Delay between a time tick and the task execution.", p.lags)
+	consumers := make(map[string]int64, len(p.consumers))
+	for name, t := range p.consumers {
+		consumers[name] = int64(t.Consumers())
+	}
+	writeGauge(&b, "goticks_ticker_consumers", "Number of ticker consumers.", "ticker", consumers)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeHeader(b *strings.Builder, name, help, typ string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func writeCounter(b *strings.Builder, name, help string, values map[string]uint64) {
+	if len(values) == 0 {
+		return
+	}
+	writeHeader(b, name, help, "counter")
+	for _, task := range slices.Sorted(maps.Keys(values)) {
+		fmt.Fprintf(b, "%s{task=%s} %d\n", name, quote(task), values[task])
+	}
+}
+
+func writeGauge(b *strings.Builder, name, help, label string, values map[string]int64) {
+	if len(values) == 0 {
+		return
+	}
+	writeHeader(b, name, help, "gauge")
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		fmt.Fprintf(b, "%s{%s=%s} %d\n", name, label, quote(key), values[key])
+	}
+}
+
+func (p *Prometheus) writeHistogram(b *strings.Builder, name, help string, values map[string]*histogram) {
+	if len(values) == 0 {
+		return
+	}
+	writeHeader(b, name, help, "histogram")
+	for _, task := range slices.Sorted(maps.Keys(values)) {
+		h := values[task]
+		for i, le := range p.buckets {
+			fmt.Fprintf(b, "%s_bucket{task=%s,le=\"%s\"} %d\n", name, quote(task),
+				strconv.FormatFloat(le, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(b, "%s_bucket{task=%s,le=\"+Inf\"} %d\n", name, quote(task), h.count)
+		fmt.Fprintf(b, "%s_sum{task=%s} %s\n", name, quote(task), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(b, "%s_count{task=%s} %d\n", name, quote(task), h.count)
+	}
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote escapes and quotes a label value.
+func quote(value string) string {
+	return `"` + labelReplacer.Replace(value) + `"`
+}
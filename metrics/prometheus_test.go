@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/ticker"
+)
+
+func TestPrometheus(t *testing.T) {
+	p := NewPrometheus(0.1, 1)
+	task := Record[int](p, `a "b"`, func(tick int) error {
+		if tick > 0 {
+			return errors.New("test")
+		}
+		return nil
+	})
+	_ = task(context.Background(), 0)
+	_ = task(context.Background(), 1)
+	p.AddRetry(`a "b"`)
+	p.AddSkipped("c")
+	p.ObserveTickLag("c", 500*time.Millisecond)
+
+	tt := ticker.New[int]()
+	_ = tt.Ticks()
+	p.ObserveConsumers("t", tt.(ticker.Observable))
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(w.Result().Body)
+	lines := strings.Split(string(body), "\n")
+
+	assert.That(t,
+		assert.Equal("text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type")),
+		assert.EqualSlices([]string{
+			"# HELP goticks_task_runs_total Number of task executions.",
+			"# TYPE goticks_task_runs_total counter",
+			`goticks_task_runs_total{task="a \"b\""} 2`,
+			"# HELP goticks_task_failures_total Number of failed task executions.",
+			"# TYPE goticks_task_failures_total counter",
+			`goticks_task_failures_total{task="a \"b\""} 1`,
+			"# HELP goticks_task_retries_total Number of task retries.",
+			"# TYPE goticks_task_retries_total counter",
+			`goticks_task_retries_total{task="a \"b\""} 1`,
+			"# HELP goticks_task_skipped_total Number of task executions skipped due to an overlap.",
+			"# TYPE goticks_task_skipped_total counter",
+			`goticks_task_skipped_total{task="c"} 1`,
+			"# HELP goticks_task_in_flight Number of running task executions.",
+			"# TYPE goticks_task_in_flight gauge",
+			`goticks_task_in_flight{task="a \"b\""} 0`,
+		}, lines[:15]),
+		assert.EqualSlices([]string{
+			"# HELP goticks_task_tick_lag_seconds Delay between a time tick and the task execution.",
+			"# TYPE goticks_task_tick_lag_seconds histogram",
+			`goticks_task_tick_lag_seconds_bucket{task="c",le="0.1"} 0`,
+			`goticks_task_tick_lag_seconds_bucket{task="c",le="1"} 1`,
+			`goticks_task_tick_lag_seconds_bucket{task="c",le="+Inf"} 1`,
+			`goticks_task_tick_lag_seconds_sum{task="c"} 0.5`,
+			`goticks_task_tick_lag_seconds_count{task="c"} 1`,
+			"# HELP goticks_ticker_consumers Number of ticker consumers.",
+			"# TYPE goticks_ticker_consumers gauge",
+			`goticks_ticker_consumers{ticker="t"} 1`,
+			"",
+		}, lines[len(lines)-11:]))
+}
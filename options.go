@@ -1,9 +1,35 @@
 package goticks
 
+import (
+	"context"
+	"time"
+
+	"github.com/parametalol/goticks/lease"
+)
+
 type options struct {
 	onStart    func() error
 	onStop     func()
 	stopTicker bool
+	// beforeRun and afterRun hooks are stored untyped, and are checked
+	// against the task tick type by NewTask, which panics on a mismatch.
+	beforeRun []any
+	afterRun  []any
+
+	store         Store
+	name          string
+	period        time.Duration
+	misfirePolicy MisfirePolicy
+
+	locker   lease.Locker
+	leaseTTL time.Duration
+
+	maxRuns     int64
+	maxAttempts int64
+	notBefore   time.Time
+	notAfter    time.Time
+
+	triggerReset bool
 }
 
 type option func(*options)
@@ -25,3 +51,113 @@ func WithTickerStop() option {
 		o.stopTicker = true
 	}
 }
+
+// WithBeforeRun adds a hook, called before every task execution. The hooks are
+// called in the order of registration, each receiving the context returned by
+// the previous one. The task is called with the context returned by the last
+// hook.
+//
+// If a hook returns an error, the tick is skipped: the rest of the hooks, the
+// task and the after-run hooks are not called. Return [utils.ErrStopped] to
+// stop the task loop.
+//
+// The hook tick type must match the task tick type, otherwise [NewTask]
+// panics.
+func WithBeforeRun[TickType any](f func(context.Context, TickType) (context.Context, error)) option {
+	return func(o *options) {
+		o.beforeRun = append(o.beforeRun, f)
+	}
+}
+
+// WithAfterRun adds a hook, called after every task execution with the task
+// error and the execution duration. The hooks are called in the order of
+// registration.
+//
+// The hook tick type must match the task tick type, otherwise [NewTask]
+// panics.
+func WithAfterRun[TickType any](f func(ctx context.Context, tick TickType, err error, duration time.Duration)) option {
+	return func(o *options) {
+		o.afterRun = append(o.afterRun, f)
+	}
+}
+
+// WithStore records the last attempted and the last successful run of the
+// named task in the store. Errors of saving the state are ignored.
+func WithStore(store Store, name string) option {
+	return func(o *options) {
+		o.store = store
+		o.name = name
+	}
+}
+
+// WithMisfirePolicy makes the task compute its first run from the state,
+// persisted with [WithStore], instead of running immediately on [Start].
+// The task runs the slots, missed since the last attempted run, according to
+// the policy, and starts consuming the ticker at the next slot, keeping the
+// phase of the previous runs. For the [time.Time] ticks, the catch-up runs
+// receive the slot time as the tick, and the zero tick value otherwise.
+//
+// The phase is only kept if the ticker starts on the first call to
+// [ticker.Tickable.Ticks], as the one returned by [ticker.NewTimer].
+func WithMisfirePolicy(period time.Duration, policy MisfirePolicy) option {
+	return func(o *options) {
+		o.period = period
+		o.misfirePolicy = policy
+	}
+}
+
+// WithLease makes the task run only while holding the lease from the locker,
+// so that only one of the task instances, sharing the locker, executes the
+// ticks. The lease is acquired on a tick, renewed every third of the ttl in
+// background, and released on [Stop]. The ticks are skipped while the lease
+// is held by another instance. If the lease is lost, the running execution
+// context is cancelled with [lease.ErrLost] as the cause. The lease is not
+// renewed with a ttl too small to be divided, e.g. 0 for the lockers, that do
+// not use the TTL, such as [lease.NewFile].
+func WithLease(locker lease.Locker, ttl time.Duration) option {
+	return func(o *options) {
+		o.locker = locker
+		o.leaseTTL = ttl
+	}
+}
+
+// WithMaxRuns stops the task after n successful executions since [Start].
+// The ticks, skipped by the lease or by the before-run hooks, are not counted.
+func WithMaxRuns(n int) option {
+	return func(o *options) {
+		o.maxRuns = int64(n)
+	}
+}
+
+// WithMaxAttempts stops the task after n executions since [Start], whether
+// successful or not. The ticks, skipped by the lease or by the before-run
+// hooks, are not counted.
+func WithMaxAttempts(n int) option {
+	return func(o *options) {
+		o.maxAttempts = int64(n)
+	}
+}
+
+// WithNotBefore skips the ticks, received before the given time.
+func WithNotBefore(t time.Time) option {
+	return func(o *options) {
+		o.notBefore = t
+	}
+}
+
+// WithNotAfter stops the task at the given time.
+func WithNotAfter(t time.Time) option {
+	return func(o *options) {
+		o.notAfter = t
+	}
+}
+
+// WithTriggerReset makes [Triggerable.Trigger] of a started task reset the
+// phase of its ticker, if the ticker implements [ticker.TimeTicker] and
+// [ticker.Periodic] with a non-zero period, so that the next tick comes a full
+// period after the triggered run.
+func WithTriggerReset() option {
+	return func(o *options) {
+		o.triggerReset = true
+	}
+}
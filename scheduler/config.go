@@ -0,0 +1,265 @@
+package scheduler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parametalol/goticks/ticker"
+)
+
+// Duration is a [time.Duration], that is represented in the configuration as
+// a string, accepted by [time.ParseDuration].
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	return d.parse(s)
+}
+
+func (d *Duration) parse(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Config is the configuration of the scheduled tasks.
+type Config struct {
+	// Tasks maps the names of the registered functions to their schedules.
+	Tasks map[string]TaskConfig `json:"tasks"`
+}
+
+// TaskConfig is the configuration of a scheduled task.
+type TaskConfig struct {
+	// Period of the ticks. Either Period or Cron has to be set.
+	Period Duration `json:"period,omitempty"`
+	// Cron expression of the schedule. See [ticker.ParseCron].
+	Cron string `json:"cron,omitempty"`
+	// Jitter is the maximal random delay of the ticks.
+	Jitter Duration `json:"jitter,omitempty"`
+	// Align aligns the periodic ticks to the multiples of the period, shifted
+	// by the Offset. See [ticker.WithAlignment].
+	Align  bool     `json:"align,omitempty"`
+	Offset Duration `json:"offset,omitempty"`
+	// Timeout of every execution attempt.
+	Timeout Duration `json:"timeout,omitempty"`
+	// Retries is the number of the retries after a failed attempt, with the
+	// Backoff delay multiplied by the retry number.
+	Retries int      `json:"retries,omitempty"`
+	Backoff Duration `json:"backoff,omitempty"`
+	// Concurrency, if positive, makes the executions asynchronous to the
+	// ticks, limited to the given number at a time. The ticks over the limit
+	// are skipped.
+	Concurrency int `json:"concurrency,omitempty"`
+	// Enabled tasks are started. Defaults to true.
+	Enabled bool `json:"enabled"`
+}
+
+func (c *TaskConfig) UnmarshalJSON(data []byte) error {
+	type plain TaskConfig
+	p := plain{Enabled: true}
+	if err := decodeStrict(data, &p); err != nil {
+		return err
+	}
+	*c = TaskConfig(p)
+	return nil
+}
+
+func decodeStrict(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+func (c TaskConfig) validate() error {
+	switch {
+	case (c.Period > 0) == (c.Cron != ""):
+		return errors.New("exactly one of period and cron must be set")
+	case c.Period < 0, c.Jitter < 0, c.Timeout < 0, c.Backoff < 0:
+		return errors.New("durations must not be negative")
+	case c.Align && c.Cron != "":
+		return errors.New("align is not supported with cron")
+	case c.Retries < 0:
+		return errors.New("retries must not be negative")
+	case c.Concurrency < 0:
+		return errors.New("concurrency must not be negative")
+	}
+	if c.Cron != "" {
+		if _, err := ticker.ParseCron(c.Cron); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newTicker returns a stopped ticker for the schedule.
+func (c TaskConfig) newTicker() (ticker.TimeTicker, error) {
+	var opts []ticker.TimerOption
+	if c.Jitter > 0 {
+		opts = append(opts, ticker.WithJitter(time.Duration(c.Jitter)))
+	}
+	if c.Align {
+		opts = append(opts, ticker.WithAlignment(time.Duration(c.Offset)))
+	}
+	if c.Cron != "" {
+		return ticker.NewCron(c.Cron, opts...)
+	}
+	return ticker.NewTimer(time.Duration(c.Period), opts...), nil
+}
+
+// ParseJSON parses the JSON configuration. The unknown fields are rejected.
+//
+// Example:
+//
+//	{"tasks": {
+//		"backup": {"period": "1h", "align": true, "jitter": "5m", "timeout": "10m"},
+//		"report": {"cron": "30 9 * * mon-fri", "retries": 3, "backoff": "1s"},
+//		"cleanup": {"period": "24h", "enabled": false}
+//	}}
+func ParseJSON(r io.Reader) (Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Config{}, err
+	}
+	var config Config
+	if err := decodeStrict(data, &config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// ParseText parses the line-based configuration. Every line defines a task
+// with its name followed by the key=value settings, named as the JSON fields.
+// The values with spaces have to be double-quoted. The empty lines and the
+// lines starting with '#' are ignored.
+//
+// Example:
+//
+//	# name  settings
+//	backup  period=1h align=true jitter=5m timeout=10m
+//	report  cron="30 9 * * mon-fri" retries=3 backoff=1s
+//	cleanup period=24h enabled=false
+func ParseText(r io.Reader) (Config, error) {
+	config := Config{Tasks: make(map[string]TaskConfig)}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields, err := splitFields(line)
+		if err != nil {
+			return Config{}, fmt.Errorf("line %d: %w", n, err)
+		}
+		name := fields[0]
+		if _, ok := config.Tasks[name]; ok {
+			return Config{}, fmt.Errorf("line %d: duplicate task %q", n, name)
+		}
+		c := TaskConfig{Enabled: true}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return Config{}, fmt.Errorf("line %d: expected key=value, got %q", n, field)
+			}
+			if strings.HasPrefix(value, `"`) {
+				if value, err = strconv.Unquote(value); err != nil {
+					return Config{}, fmt.Errorf("line %d: bad quoted value of %s", n, key)
+				}
+			}
+			if err := c.set(key, value); err != nil {
+				return Config{}, fmt.Errorf("line %d: %s: %w", n, key, err)
+			}
+		}
+		config.Tasks[name] = c
+	}
+	return config, scanner.Err()
+}
+
+// splitFields splits the line by the white space outside of double quotes.
+func splitFields(line string) ([]string, error) {
+	var fields []string
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		i, quoted := 0, false
+		for ; i < len(line) && (quoted || (line[i] != ' ' && line[i] != '\t')); i++ {
+			switch {
+			case line[i] == '"':
+				quoted = !quoted
+			case line[i] == '\\' && quoted:
+				i++
+			}
+		}
+		i = min(i, len(line))
+		if quoted {
+			return nil, errors.New("unterminated quote")
+		}
+		fields = append(fields, line[:i])
+		line = line[i:]
+	}
+	return fields, nil
+}
+
+func (c *TaskConfig) set(key, value string) error {
+	var err error
+	switch key {
+	case "period":
+		err = c.Period.parse(value)
+	case "cron":
+		c.Cron = value
+	case "jitter":
+		err = c.Jitter.parse(value)
+	case "align":
+		c.Align, err = strconv.ParseBool(value)
+	case "offset":
+		err = c.Offset.parse(value)
+	case "timeout":
+		err = c.Timeout.parse(value)
+	case "retries":
+		c.Retries, err = strconv.Atoi(value)
+	case "backoff":
+		err = c.Backoff.parse(value)
+	case "concurrency":
+		c.Concurrency, err = strconv.Atoi(value)
+	case "enabled":
+		c.Enabled, err = strconv.ParseBool(value)
+	default:
+		err = errors.New("unknown setting")
+	}
+	return err
+}
+
+// Load reads the configuration file: JSON for the .json extension, and the
+// line-based format otherwise. See [ParseJSON] and [ParseText].
+func Load(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, err
+	}
+	defer func() { _ = f.Close() }()
+	parse := ParseText
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		parse = ParseJSON
+	}
+	config, err := parse(f)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestParse(t *testing.T) {
+	expected := Config{Tasks: map[string]TaskConfig{
+		"backup": {
+			Period: Duration(time.Hour), Align: true, Offset: Duration(5 * time.Minute),
+			Jitter: Duration(time.Minute), Timeout: Duration(10 * time.Minute), Enabled: true,
+		},
+		"report": {
+			Cron: "30 9 * * mon-fri", Retries: 3, Backoff: Duration(time.Second),
+			Concurrency: 2, Enabled: true,
+		},
+		"cleanup": {Period: Duration(24 * time.Hour)},
+	}}
+
+	t.Run("json", func(t *testing.T) {
+		config, err := ParseJSON(strings.NewReader(`{"tasks": {
+			"backup": {"period": "1h", "align": true, "offset": "5m", "jitter": "1m", "timeout": "10m"},
+			"report": {"cron": "30 9 * * mon-fri", "retries": 3, "backoff": "1s", "concurrency": 2},
+			"cleanup": {"period": "24h", "enabled": false}
+		}}`))
+		assert.That(t,
+			assert.NoError(err),
+			assert.True(maps.Equal(expected.Tasks, config.Tasks)))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		config, err := ParseText(strings.NewReader(`
+			# name  settings
+			backup  period=1h align=true offset=5m jitter=1m timeout=10m
+			report  cron="30 9 * * mon-fri" retries=3 backoff=1s concurrency=2
+
+			cleanup period=24h enabled=false
+		`))
+		assert.That(t,
+			assert.NoError(err),
+			assert.True(maps.Equal(expected.Tasks, config.Tasks)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, errUnknownJSON := ParseJSON(strings.NewReader(`{"tasks": {"a": {"period": "1h", "perod": "2h"}}}`))
+		_, errDurationJSON := ParseJSON(strings.NewReader(`{"tasks": {"a": {"period": 5}}}`))
+		_, errUnknown := ParseText(strings.NewReader("a period=1h\nb perod=1h"))
+		_, errQuote := ParseText(strings.NewReader(`a cron="* * * * *`))
+		_, errDuplicate := ParseText(strings.NewReader("a period=1h\na period=2h"))
+		_, errValue := ParseText(strings.NewReader("a retries=x"))
+		assert.That(t,
+			assert.Not(assert.NoError(errUnknownJSON)),
+			assert.Not(assert.NoError(errDurationJSON)),
+			assert.Equal("line 2: perod: unknown setting", errUnknown.Error()),
+			assert.Equal("line 1: unterminated quote", errQuote.Error()),
+			assert.Equal(`line 2: duplicate task "a"`, errDuplicate.Error()),
+			assert.Not(assert.NoError(errValue)))
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "schedule.json")
+	textPath := filepath.Join(dir, "schedule.conf")
+	_ = os.WriteFile(jsonPath, []byte(`{"tasks": {"a": {"period": "1s"}}}`), 0o600)
+	_ = os.WriteFile(textPath, []byte(`a period=1s`), 0o600)
+
+	jsonConfig, errJSON := Load(jsonPath)
+	textConfig, errText := Load(textPath)
+	_, errMissing := Load(filepath.Join(dir, "missing"))
+	assert.That(t,
+		assert.NoError(errJSON),
+		assert.NoError(errText),
+		assert.True(maps.Equal(jsonConfig.Tasks, textConfig.Tasks)),
+		assert.Not(assert.NoError(errMissing)))
+}
+
+func TestTaskConfig_validate(t *testing.T) {
+	for _, c := range []TaskConfig{
+		{},
+		{Period: Duration(time.Second), Cron: "* * * * *"},
+		{Cron: "* * *"},
+		{Cron: "* * * * *", Align: true},
+		{Period: Duration(time.Second), Retries: -1},
+		{Period: Duration(time.Second), Timeout: Duration(-time.Second)},
+	} {
+		assert.That(t, assert.Not(assert.NoError(c.validate())))
+	}
+	assert.That(t, assert.NoError(TaskConfig{Cron: "@daily"}.validate()))
+}
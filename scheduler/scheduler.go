@@ -0,0 +1,292 @@
+// Package scheduler runs the tasks, registered by name, on the schedules
+// loaded from a configuration, which can be reloaded without a restart.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/parametalol/goticks"
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+type options struct {
+	logger *slog.Logger
+}
+
+type Option func(*options)
+
+// WithLogger sets the logger of the task failures and the schedule changes.
+// Defaults to [slog.Default].
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+type scheduledTask struct {
+	config TaskConfig
+	ticker ticker.TimeTicker
+	task   goticks.RestartableWithTicker[time.Time]
+}
+
+// Scheduler runs the registered functions as tasks on the configured
+// schedules.
+type Scheduler struct {
+	options options
+
+	mux   sync.Mutex
+	funcs map[string]func(context.Context, time.Time) error
+	tasks map[string]*scheduledTask
+
+	// runs tracks the running executions. No executions are started while
+	// stopping, so that the runs are not added during the wait.
+	runsMux  sync.Mutex
+	stopping bool
+	runs     sync.WaitGroup
+}
+
+// New returns a scheduler without tasks.
+//
+// Example:
+//
+//	s := scheduler.New()
+//	s.Register("backup", backup)
+//	if err := s.Reload("schedule.json"); err != nil {
+//		...
+//	}
+//	defer s.Stop()
+func New(opts ...Option) *Scheduler {
+	s := &Scheduler{
+		options: options{logger: slog.Default()},
+		funcs:   make(map[string]func(context.Context, time.Time) error),
+		tasks:   make(map[string]*scheduledTask),
+	}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	return s
+}
+
+// Register makes the function available for the configuration under the name.
+// Use [utils.AdaptT] to register the functions of other signatures.
+func (s *Scheduler) Register(name string, fn func(context.Context, time.Time) error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.funcs[name] = fn
+}
+
+// Reload loads the configuration file and applies it. See [Load] and
+// [Scheduler.Apply].
+func (s *Scheduler) Reload(path string) error {
+	config, err := Load(path)
+	if err != nil {
+		return err
+	}
+	return s.Apply(config)
+}
+
+// Apply validates the configuration and brings the tasks to it:
+//   - the new enabled tasks are started, and the removed tasks are stopped;
+//   - the period changes are applied with [ticker.TimeTicker.Reset], keeping
+//     the task running;
+//   - the tasks are started or stopped on the enabled flag toggle;
+//   - the tasks with other changes are restarted with the new configuration.
+//
+// If the configuration is not valid, nothing is changed.
+func (s *Scheduler) Apply(config Config) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(config.Tasks)) {
+		if _, ok := s.funcs[name]; !ok {
+			errs = append(errs, fmt.Errorf("task %q: function is not registered", name))
+		} else if err := config.Tasks[name].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("task %q: %w", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for name, st := range s.tasks {
+		if _, ok := config.Tasks[name]; !ok {
+			st.task.Stop()
+			delete(s.tasks, name)
+			s.options.logger.Info("task removed", "task", name)
+		}
+	}
+	for name, c := range config.Tasks {
+		st, ok := s.tasks[name]
+		switch {
+		case !ok:
+		case st.config == c:
+			continue
+		case onlyChanged(st.config, c, func(c *TaskConfig) { c.Period = 0 }) && c.Enabled:
+			st.config = c
+			st.ticker.Reset(time.Duration(c.Period))
+			s.options.logger.Info("task period changed", "task", name, "period", time.Duration(c.Period))
+			continue
+		case onlyChanged(st.config, c, func(c *TaskConfig) { c.Enabled = false }):
+			st.config = c
+			if c.Enabled {
+				st.task.Start()
+				s.options.logger.Info("task enabled", "task", name)
+			} else {
+				st.task.Stop()
+				s.options.logger.Info("task disabled", "task", name)
+			}
+			continue
+		default:
+			st.task.Stop()
+		}
+		st, err := s.newTask(name, c)
+		if err != nil {
+			// Should not happen after the validation.
+			errs = append(errs, fmt.Errorf("task %q: %w", name, err))
+			continue
+		}
+		s.tasks[name] = st
+		if c.Enabled {
+			st.task.Start()
+		}
+		s.options.logger.Info("task scheduled", "task", name, "enabled", c.Enabled)
+	}
+	return errors.Join(errs...)
+}
+
+// onlyChanged tells whether a and b are equal after the reset.
+func onlyChanged(a, b TaskConfig, reset func(*TaskConfig)) bool {
+	reset(&a)
+	reset(&b)
+	return a == b
+}
+
+func (s *Scheduler) newTask(name string, c TaskConfig) (*scheduledTask, error) {
+	t, err := c.newTicker()
+	if err != nil {
+		return nil, err
+	}
+	fn := s.funcs[name]
+	if c.Timeout > 0 {
+		fn = utils.Timeout[time.Time](time.Duration(c.Timeout), fn)
+	}
+	if c.Retries > 0 {
+		policy := utils.SimpleRetryPolicy(c.Retries + 1)
+		if c.Backoff > 0 {
+			policy = utils.ExponentialBackoffPolicy(c.Retries+1, time.Duration(c.Backoff))
+		}
+		fn = utils.Retry[time.Time](policy, fn)
+	}
+	fn = s.track(name, fn)
+	if c.Concurrency > 0 {
+		fn = s.async(name, c.Concurrency, fn)
+	}
+	return &scheduledTask{
+		config: c,
+		ticker: t,
+		task:   goticks.NewTask(t, fn, goticks.WithTickerStop()),
+	}, nil
+}
+
+// track counts the running executions, and logs the failures.
+func (s *Scheduler) track(name string, fn func(context.Context, time.Time) error) func(context.Context, time.Time) error {
+	return func(ctx context.Context, tick time.Time) error {
+		if !s.begin() {
+			return nil
+		}
+		defer s.runs.Done()
+		err := fn(ctx, tick)
+		if err != nil {
+			s.options.logger.Error("task failed", "task", name, "error", err)
+		}
+		return err
+	}
+}
+
+// async runs the executions in the background, up to the limit at a time.
+func (s *Scheduler) async(name string, limit int, fn func(context.Context, time.Time) error) func(context.Context, time.Time) error {
+	sem := utils.NewSemaphore(int64(limit))
+	return func(ctx context.Context, tick time.Time) error {
+		if !sem.TryAcquire(1) {
+			s.options.logger.Warn("task run skipped", "task", name, "error", utils.ErrBulkheadFull)
+			return nil
+		}
+		if !s.begin() {
+			sem.Release(1)
+			return nil
+		}
+		go func() {
+			defer s.runs.Done()
+			defer sem.Release(1)
+			// The background runs are not cancelled by the tick loop.
+			_ = fn(context.WithoutCancel(ctx), tick)
+		}()
+		return nil
+	}
+}
+
+// begin registers a run, unless the scheduler is stopping.
+func (s *Scheduler) begin() bool {
+	s.runsMux.Lock()
+	defer s.runsMux.Unlock()
+	if s.stopping {
+		return false
+	}
+	s.runs.Add(1)
+	return true
+}
+
+// Task returns the scheduled task by name.
+func (s *Scheduler) Task(name string) (goticks.RestartableWithTicker[time.Time], bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	st, ok := s.tasks[name]
+	if !ok {
+		return nil, false
+	}
+	return st.task, true
+}
+
+// Config returns the applied configuration of the task.
+func (s *Scheduler) Config(name string) (TaskConfig, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	st, ok := s.tasks[name]
+	if !ok {
+		return TaskConfig{}, false
+	}
+	return st.config, true
+}
+
+// Names returns the sorted names of the scheduled tasks.
+func (s *Scheduler) Names() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return slices.Sorted(maps.Keys(s.tasks))
+}
+
+// Stop stops all the tasks, and waits for the running executions to finish.
+// The tasks can be started again with [Scheduler.Apply].
+func (s *Scheduler) Stop() {
+	s.mux.Lock()
+	for name, st := range s.tasks {
+		st.task.Stop()
+		delete(s.tasks, name)
+	}
+	s.mux.Unlock()
+	s.runsMux.Lock()
+	s.stopping = true
+	s.runsMux.Unlock()
+	s.runs.Wait()
+	s.runsMux.Lock()
+	s.stopping = false
+	s.runsMux.Unlock()
+}
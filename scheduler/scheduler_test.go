@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/ticker"
+)
+
+func newTestScheduler() (*Scheduler, *atomic.Int32) {
+	s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	var runs atomic.Int32
+	s.Register("count", func(context.Context, time.Time) error {
+		runs.Add(1)
+		return nil
+	})
+	return s, &runs
+}
+
+func period(d time.Duration, enabled bool) Config {
+	return Config{Tasks: map[string]TaskConfig{
+		"count": {Period: Duration(d), Enabled: enabled},
+	}}
+}
+
+func TestScheduler_Apply(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		s, _ := newTestScheduler()
+		defer s.Stop()
+		err := s.Apply(Config{Tasks: map[string]TaskConfig{
+			"count":   {},
+			"missing": {Period: Duration(time.Second)},
+		}})
+		assert.That(t,
+			assert.Equal(`task "count": exactly one of period and cron must be set
+task "missing": function is not registered`, err.Error()),
+			assert.Equal(0, len(s.Names())))
+	})
+
+	t.Run("period change", func(t *testing.T) {
+		s, runs := newTestScheduler()
+		defer s.Stop()
+		assert.That(t, assert.NoError(s.Apply(period(time.Hour, true))))
+		task, _ := s.Task("count")
+		time.Sleep(50 * time.Millisecond)
+		assert.That(t,
+			assert.Equal(int32(1), runs.Load()),
+			assert.NoError(s.Apply(period(100*time.Millisecond, true))))
+		time.Sleep(250 * time.Millisecond)
+		same, _ := s.Task("count")
+		assert.That(t,
+			assert.Equal(int32(3), runs.Load()),
+			assert.True(task == same),
+			assert.Equal(100*time.Millisecond, task.Ticker().(ticker.Periodic).Period()))
+	})
+
+	t.Run("toggle", func(t *testing.T) {
+		s, runs := newTestScheduler()
+		defer s.Stop()
+		assert.That(t, assert.NoError(s.Apply(period(time.Hour, false))))
+		time.Sleep(50 * time.Millisecond)
+		assert.That(t,
+			assert.Equal(int32(0), runs.Load()),
+			assert.NoError(s.Apply(period(time.Hour, true))))
+		time.Sleep(50 * time.Millisecond)
+		assert.That(t,
+			assert.Equal(int32(1), runs.Load()),
+			assert.NoError(s.Apply(period(time.Hour, false))))
+		config, _ := s.Config("count")
+		assert.That(t, assert.False(config.Enabled))
+	})
+
+	t.Run("restart and remove", func(t *testing.T) {
+		s, runs := newTestScheduler()
+		defer s.Stop()
+		assert.That(t, assert.NoError(s.Apply(period(time.Hour, true))))
+		task, _ := s.Task("count")
+		time.Sleep(50 * time.Millisecond)
+		restarted := period(time.Hour, true)
+		restarted.Tasks["count"] = TaskConfig{Period: Duration(time.Hour), Timeout: Duration(time.Second), Enabled: true}
+		assert.That(t, assert.NoError(s.Apply(restarted)))
+		time.Sleep(50 * time.Millisecond)
+		other, _ := s.Task("count")
+		assert.That(t,
+			assert.Equal(int32(2), runs.Load()),
+			assert.False(task == other),
+			assert.NoError(s.Apply(Config{})),
+			assert.Equal(0, len(s.Names())))
+	})
+}
+
+func TestScheduler_task(t *testing.T) {
+	t.Run("retries", func(t *testing.T) {
+		s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+		defer s.Stop()
+		var attempts atomic.Int32
+		s.Register("fail", func(ctx context.Context, _ time.Time) error {
+			attempts.Add(1)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		assert.That(t, assert.NoError(s.Apply(Config{Tasks: map[string]TaskConfig{
+			"fail": {Period: Duration(time.Hour), Timeout: Duration(10 * time.Millisecond), Retries: 2, Enabled: true},
+		}})))
+		time.Sleep(100 * time.Millisecond)
+		assert.That(t, assert.Equal(int32(3), attempts.Load()))
+	})
+
+	t.Run("concurrency", func(t *testing.T) {
+		s := New(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+		release := make(chan struct{})
+		var running atomic.Int32
+		s.Register("slow", func(context.Context, time.Time) error {
+			running.Add(1)
+			<-release
+			return errors.New("done")
+		})
+		assert.That(t, assert.NoError(s.Apply(Config{Tasks: map[string]TaskConfig{
+			"slow": {Period: Duration(20 * time.Millisecond), Concurrency: 2, Enabled: true},
+		}})))
+		time.Sleep(100 * time.Millisecond)
+		assert.That(t, assert.Equal(int32(2), running.Load()))
+
+		stopped := make(chan struct{})
+		go func() {
+			s.Stop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			t.Error("Stop did not wait for the running executions")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(release)
+		<-stopped
+	})
+}
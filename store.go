@@ -0,0 +1,154 @@
+package goticks
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RunState is the persisted state of a task.
+type RunState struct {
+	LastAttempt time.Time `json:"last_attempt"`
+	LastSuccess time.Time `json:"last_success"`
+}
+
+// Store persists the task run states between process restarts.
+// The implementations must be safe for concurrent use.
+type Store interface {
+	// Load returns the state of the named task. It returns zero state if
+	// nothing has been saved yet.
+	Load(name string) (RunState, error)
+	// Save stores the state of the named task.
+	Save(name string, state RunState) error
+}
+
+type memoryStore struct {
+	mux    sync.Mutex
+	states map[string]RunState
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a store, that keeps the states in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{states: make(map[string]RunState)}
+}
+
+func (s *memoryStore) Load(name string) (RunState, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.states[name], nil
+}
+
+func (s *memoryStore) Save(name string, state RunState) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.states[name] = state
+	return nil
+}
+
+type fileStore struct {
+	path string
+	mux  sync.Mutex
+}
+
+var _ Store = (*fileStore)(nil)
+
+// NewFileStore returns a store, that keeps the states of all tasks in a single
+// JSON file. The file is replaced atomically on every save.
+func NewFileStore(path string) Store {
+	return &fileStore{path: path}
+}
+
+func (s *fileStore) read() (map[string]RunState, error) {
+	states := make(map[string]RunState)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return states, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+func (s *fileStore) Load(name string) (RunState, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	states, err := s.read()
+	if err != nil {
+		return RunState{}, err
+	}
+	return states[name], nil
+}
+
+func (s *fileStore) Save(name string, state RunState) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	states, err := s.read()
+	if err != nil {
+		return err
+	}
+	states[name] = state
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// MisfirePolicy defines what to do with the runs, missed while the process was
+// not running.
+type MisfirePolicy int
+
+const (
+	// MisfireRunOnce runs the task once for the last missed slot.
+	MisfireRunOnce MisfirePolicy = iota
+	// MisfireRunAll runs the task for every missed slot.
+	MisfireRunAll
+	// MisfireSkip skips the missed runs and waits for the next slot.
+	MisfireSkip
+)
+
+// misfire computes the missed slots, that have to be run according to the
+// policy, and the time of the next regular run.
+func misfire(state RunState, now time.Time, period time.Duration, policy MisfirePolicy) ([]time.Time, time.Time) {
+	if state.LastAttempt.IsZero() || period <= 0 {
+		return nil, now
+	}
+	n := int(now.Sub(state.LastAttempt) / period)
+	next := state.LastAttempt.Add(time.Duration(n+1) * period)
+	if n < 1 {
+		return nil, next
+	}
+	switch policy {
+	case MisfireRunOnce:
+		return []time.Time{state.LastAttempt.Add(time.Duration(n) * period)}, next
+	case MisfireRunAll:
+		slots := make([]time.Time, 0, n)
+		for i := 1; i <= n; i++ {
+			slots = append(slots, state.LastAttempt.Add(time.Duration(i)*period))
+		}
+		return slots, next
+	}
+	return nil, next
+}
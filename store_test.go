@@ -0,0 +1,151 @@
+package goticks
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+func TestStore(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	for name, store := range map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   NewFileStore(filepath.Join(t.TempDir(), "state.json")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			state, err := store.Load("a")
+			assert.That(t,
+				assert.NoError(err),
+				assert.True(state.LastAttempt.IsZero()))
+
+			assert.That(t,
+				assert.NoError(store.Save("a", RunState{LastAttempt: now, LastSuccess: now.Add(-time.Hour)})),
+				assert.NoError(store.Save("b", RunState{LastAttempt: now})))
+
+			state, err = store.Load("a")
+			assert.That(t,
+				assert.NoError(err),
+				assert.True(now.Equal(state.LastAttempt)),
+				assert.True(now.Add(-time.Hour).Equal(state.LastSuccess)))
+		})
+	}
+}
+
+func Test_misfire(t *testing.T) {
+	now := time.Now()
+	last := now.Add(-250 * time.Minute)
+	state := RunState{LastAttempt: last}
+	next := last.Add(300 * time.Minute)
+
+	t.Run("no state", func(t *testing.T) {
+		missed, first := misfire(RunState{}, now, time.Hour, MisfireRunAll)
+		assert.That(t,
+			assert.Equal(0, len(missed)),
+			assert.True(now.Equal(first)))
+	})
+	t.Run("not missed", func(t *testing.T) {
+		missed, first := misfire(state, now, 5*time.Hour, MisfireRunAll)
+		assert.That(t,
+			assert.Equal(0, len(missed)),
+			assert.True(last.Add(5*time.Hour).Equal(first)))
+	})
+	t.Run("run once", func(t *testing.T) {
+		missed, first := misfire(state, now, time.Hour, MisfireRunOnce)
+		assert.That(t,
+			assert.EqualSlices([]time.Time{last.Add(4 * time.Hour)}, missed),
+			assert.True(next.Equal(first)))
+	})
+	t.Run("run all", func(t *testing.T) {
+		missed, first := misfire(state, now, time.Hour, MisfireRunAll)
+		assert.That(t,
+			assert.EqualSlices([]time.Time{
+				last.Add(time.Hour), last.Add(2 * time.Hour),
+				last.Add(3 * time.Hour), last.Add(4 * time.Hour),
+			}, missed),
+			assert.True(next.Equal(first)))
+	})
+	t.Run("skip", func(t *testing.T) {
+		missed, first := misfire(state, now, time.Hour, MisfireSkip)
+		assert.That(t,
+			assert.Equal(0, len(missed)),
+			assert.True(next.Equal(first)))
+	})
+}
+
+func TestTask_catchUp(t *testing.T) {
+	store := NewMemoryStore()
+	last := time.Now().Add(-250 * time.Millisecond)
+	_ = store.Save("task", RunState{LastAttempt: last, LastSuccess: last})
+
+	ticker := ticker.New[time.Time]()
+	var mux sync.Mutex
+	var ticks []time.Time
+	task := NewTask(ticker, func(tick time.Time) {
+		mux.Lock()
+		defer mux.Unlock()
+		ticks = append(ticks, tick)
+	},
+		WithStore(store, "task"),
+		WithMisfirePolicy(100*time.Millisecond, MisfireRunAll))
+	task.Start()
+	// Ticks before the next slot are not consumed.
+	ticker.Tick(time.Time{}).Wait()
+
+	time.Sleep(100 * time.Millisecond)
+	tick := last.Add(300 * time.Millisecond)
+	ticker.Tick(tick).Wait()
+	task.Stop()
+
+	state, _ := store.Load("task")
+	mux.Lock()
+	defer mux.Unlock()
+	assert.That(t,
+		assert.True(slices.EqualFunc([]time.Time{
+			last.Add(100 * time.Millisecond),
+			last.Add(200 * time.Millisecond),
+			tick,
+		}, ticks, time.Time.Equal)),
+		assert.True(tick.Equal(state.LastSuccess)))
+}
+
+func TestTask_catchUpStop(t *testing.T) {
+	t.Run("stopped by the task", func(t *testing.T) {
+		store := NewMemoryStore()
+		_ = store.Save("task", RunState{LastAttempt: time.Now().Add(-time.Hour)})
+		stopped := make(chan struct{})
+		task := NewTask(ticker.New[time.Time](), func() error {
+			return utils.ErrStopped
+		},
+			WithStore(store, "task"),
+			WithMisfirePolicy(time.Minute, MisfireRunOnce),
+			WithOnStop(func() { close(stopped) }))
+		task.Start()
+		<-stopped
+		assert.That(t, assert.False(task.Started()))
+	})
+
+	t.Run("cancelled on stop", func(t *testing.T) {
+		store := NewMemoryStore()
+		_ = store.Save("task", RunState{LastAttempt: time.Now().Add(-time.Hour)})
+		running := make(chan struct{})
+		cause := make(chan error, 1)
+		task := NewTask(ticker.New[time.Time](), func(ctx context.Context) {
+			close(running)
+			<-ctx.Done()
+			cause <- ctx.Err()
+		},
+			WithStore(store, "task"),
+			WithMisfirePolicy(time.Minute, MisfireRunOnce))
+		task.Start()
+		<-running
+		task.Stop()
+		assert.That(t, assert.ErrorIs(<-cause, context.Canceled))
+	})
+}
@@ -3,7 +3,10 @@ package goticks
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/parametalol/goticks/loop"
 	"github.com/parametalol/goticks/ticker"
@@ -17,19 +20,40 @@ type Task interface {
 
 type taskImpl[TickType any] struct {
 	ticker ticker.Tickable[TickType]
-	task   func(context.Context, TickType) error
+	// run executes the task with all the wrappers.
+	run func(context.Context, TickType) error
+	// task is run gated by the active state.
+	task func(context.Context, TickType) error
 
 	options options
 
 	once    atomic.Bool
 	started atomic.Bool
+	// active is cleared on Stop after the ticker is stopped, so that the ticks,
+	// flushed by the ticker on Stop, are still executed.
+	active atomic.Bool
+
+	mux sync.Mutex
+	// cancelCatchUp is set while the missed runs are caught up, before the
+	// task loop is started.
+	cancelCatchUp context.CancelFunc
+
+	keeper *leaseKeeper
+
+	expired       atomic.Bool
+	runs          atomic.Int64
+	attempts      atomic.Int64
+	notAfterTimer *time.Timer
 }
 
 var _ Task = (*taskImpl[any])(nil)
 
 type RestartableWithTicker[TickType any] interface {
 	ticker.Restartable
+	Triggerable
+	Started() bool
 	Ticker() ticker.Tickable[TickType]
+	TriggerWith(context.Context, TickType) error
 }
 
 // NewTask returns an instance of a restartable task, executed on the ticker
@@ -53,16 +77,77 @@ func NewTask[TickType any, Fn utils.Func[TickType]](ticker ticker.Tickable[TickT
 	for _, opt := range opts {
 		opt(&task.options)
 	}
-	adaptedTask := utils.Adapt[TickType](fn)
+	if task.options.locker != nil {
+		task.keeper = &leaseKeeper{locker: task.options.locker, ttl: task.options.leaseTTL}
+	}
+	task.run = task.withWindow(withLease(task.keeper, withHooks(&task.options,
+		task.withRunLimits(withStore(&task.options, utils.Adapt[TickType](fn))))))
 	task.task = func(ctx context.Context, tick TickType) error {
-		if !task.started.Load() {
+		if !task.active.Load() {
 			return nil
 		}
-		return adaptedTask(ctx, tick)
+		return task.run(ctx, tick)
 	}
 	return task
 }
 
+// withHooks wraps the task with the before-run and after-run hooks.
+func withHooks[TickType any](o *options, task func(context.Context, TickType) error) func(context.Context, TickType) error {
+	if len(o.beforeRun) == 0 && len(o.afterRun) == 0 {
+		return task
+	}
+	before := make([]func(context.Context, TickType) (context.Context, error), len(o.beforeRun))
+	for i, hook := range o.beforeRun {
+		var ok bool
+		if before[i], ok = hook.(func(context.Context, TickType) (context.Context, error)); !ok {
+			panic(fmt.Sprintf("goticks: before-run hook %T does not match the task tick type", hook))
+		}
+	}
+	after := make([]func(context.Context, TickType, error, time.Duration), len(o.afterRun))
+	for i, hook := range o.afterRun {
+		var ok bool
+		if after[i], ok = hook.(func(context.Context, TickType, error, time.Duration)); !ok {
+			panic(fmt.Sprintf("goticks: after-run hook %T does not match the task tick type", hook))
+		}
+	}
+	return func(ctx context.Context, tick TickType) error {
+		var err error
+		for _, hook := range before {
+			if ctx, err = hook(ctx, tick); err != nil {
+				return err
+			}
+		}
+		start := time.Now()
+		err = task(ctx, tick)
+		duration := time.Since(start)
+		for _, hook := range after {
+			hook(ctx, tick, err, duration)
+		}
+		return err
+	}
+}
+
+// withStore wraps the task with the run state recording.
+func withStore[TickType any](o *options, task func(context.Context, TickType) error) func(context.Context, TickType) error {
+	if o.store == nil {
+		return task
+	}
+	return func(ctx context.Context, tick TickType) error {
+		attempt, ok := any(tick).(time.Time)
+		if !ok {
+			attempt = time.Now()
+		}
+		err := task(ctx, tick)
+		state, _ := o.store.Load(o.name)
+		state.LastAttempt = attempt
+		if err == nil {
+			state.LastSuccess = attempt
+		}
+		_ = o.store.Save(o.name, state)
+		return err
+	}
+}
+
 // Start the task execution loop, once.
 func (t *taskImpl[TickType]) Start() {
 	if t.started.Swap(true) {
@@ -72,7 +157,13 @@ func (t *taskImpl[TickType]) Start() {
 		t.started.Store(false)
 		return
 	}
+	t.active.Store(true)
+	t.startLimits()
 	if !t.once.Swap(true) {
+		if t.options.store != nil && t.options.period > 0 {
+			t.catchUp()
+			return
+		}
 		ticks := t.ticker.Ticks()
 		go func() {
 			_ = loop.OnTick(ticks, t.task)
@@ -80,22 +171,76 @@ func (t *taskImpl[TickType]) Start() {
 	}
 }
 
+// catchUp runs the missed slots according to the misfire policy, and starts
+// the task loop at the next slot, unless the task is stopped meanwhile.
+func (t *taskImpl[TickType]) catchUp() {
+	state, _ := t.options.store.Load(t.options.name)
+	missed, next := misfire(state, time.Now(), t.options.period, t.options.misfirePolicy)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.mux.Lock()
+	t.cancelCatchUp = cancel
+	t.mux.Unlock()
+	go func() {
+		defer cancel()
+		for _, slot := range missed {
+			tick, _ := any(slot).(TickType)
+			if errors.Is(t.task(ctx, tick), utils.ErrStopped) {
+				t.Stop()
+				return
+			}
+		}
+		timer := time.NewTimer(time.Until(next))
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		t.mux.Lock()
+		if ctx.Err() != nil {
+			t.mux.Unlock()
+			return
+		}
+		t.cancelCatchUp = nil
+		t.mux.Unlock()
+		_ = loop.OnTick(t.ticker.Ticks(), t.task)
+	}()
+}
+
 // Stop all running loops by stopping the ticker.
 func (t *taskImpl[TickType]) Stop() {
 	if !t.started.Swap(false) {
 		return
 	}
+	t.stopLimits()
+	// The catch-up is restarted on the next Start.
+	t.mux.Lock()
+	if t.cancelCatchUp != nil {
+		t.cancelCatchUp()
+		t.cancelCatchUp = nil
+		t.once.Store(false)
+	}
+	t.mux.Unlock()
 	if t.options.stopTicker {
 		if ticker, isStoppable := t.ticker.(ticker.Stoppable); isStoppable {
 			ticker.Stop()
 			t.once.Store(false)
 		}
 	}
+	t.active.Store(false)
+	if t.keeper != nil {
+		t.keeper.release()
+	}
 	if t.options.onStop != nil {
 		t.options.onStop()
 	}
 }
 
+// Started reports whether the task is started.
+func (t *taskImpl[TickType]) Started() bool {
+	return t.started.Load()
+}
+
 // Ticker returns the ticker, used for the task initialization.
 func (t *taskImpl[TickType]) Ticker() ticker.Tickable[TickType] {
 	return t.ticker
@@ -1,7 +1,9 @@
 package goticks
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -160,4 +162,70 @@ func Test_options(t *testing.T) {
 		assert.That(t,
 			assert.EqualSlices([]int{1, 101}, ticks))
 	})
+
+	t.Run("ticks flushed on WithTickerStop", func(t *testing.T) {
+		src := ticker.New[int]()
+
+		var batches [][]int
+		task := NewTask(ticker.Batch[int](src, 10, 0), func(batch []int) {
+			batches = append(batches, batch)
+		}, WithTickerStop())
+		task.Start()
+		src.Tick(1).Wait()
+		src.Tick(2).Wait()
+		task.Stop()
+		assert.That(t,
+			assert.Equal(1, len(batches)),
+			assert.EqualSlices([]int{1, 2}, batches[0]))
+	})
+
+	t.Run("before and after run", func(t *testing.T) {
+		ticker := ticker.New[int]()
+
+		type ctxKey struct{}
+		var events []string
+		before := func(name string) func(context.Context, int) (context.Context, error) {
+			return func(ctx context.Context, tick int) (context.Context, error) {
+				events = append(events, fmt.Sprint("before ", name, " ", tick))
+				if tick == 2 {
+					return ctx, errors.New("skip")
+				}
+				return context.WithValue(ctx, ctxKey{}, name), nil
+			}
+		}
+		errTest := errors.New("test")
+		task := NewTask(ticker, func(ctx context.Context, tick int) error {
+			events = append(events, fmt.Sprint("run ", ctx.Value(ctxKey{}), " ", tick))
+			if tick == 3 {
+				return errTest
+			}
+			return nil
+		},
+			WithBeforeRun(before("a")),
+			WithBeforeRun(before("b")),
+			WithAfterRun(func(ctx context.Context, tick int, err error, d time.Duration) {
+				events = append(events, fmt.Sprint("after ", tick, " ", err))
+			}))
+		task.Start()
+		ticker.Tick(1).Wait()
+		ticker.Tick(2).Wait()
+		ticker.Tick(3).Wait()
+		task.Stop()
+
+		assert.That(t,
+			assert.EqualSlices([]string{
+				"before a 1", "before b 1", "run b 1", "after 1 <nil>",
+				"before a 2",
+				"before a 3", "before b 3", "run b 3", "after 3 test",
+			}, events))
+	})
+
+	t.Run("hook type mismatch", func(t *testing.T) {
+		defer func() {
+			assert.That(t, assert.True(recover() != nil))
+		}()
+		NewTask(ticker.New[int](), func() {},
+			WithAfterRun(func(context.Context, string, error, time.Duration) {}))
+		t.Error("expected panic")
+	})
 }
@@ -0,0 +1,131 @@
+package ticker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Feedback is the result of a task execution, that drives the interval of an
+// [AdaptiveTicker].
+type Feedback int32
+
+const (
+	// MoreWork tells that there is more work to do.
+	MoreWork Feedback = iota
+	// Idle tells that there was nothing to do.
+	Idle
+	// Failed tells that the execution failed.
+	Failed
+
+	noFeedback Feedback = -1
+)
+
+// Strategy computes the next interval of an [AdaptiveTicker] from the current
+// one and the task feedback. The result is clamped to the ticker bounds.
+type Strategy func(current time.Duration, feedback Feedback) time.Duration
+
+// ExponentialStrategy returns a strategy, that resets the interval to the
+// minimum on [MoreWork], and multiplies it by the factor otherwise.
+func ExponentialStrategy(factor float64) Strategy {
+	return func(current time.Duration, feedback Feedback) time.Duration {
+		if feedback == MoreWork {
+			return 0
+		}
+		return time.Duration(float64(current) * factor)
+	}
+}
+
+// AdaptiveTicker is a time ticker, which interval is adjusted by the feedback
+// from the task after every tick.
+type AdaptiveTicker interface {
+	TimeTicker
+	// Feedback reports the result of the current tick processing. The last
+	// reported feedback is applied once the tick is processed by all the
+	// consumers. Without feedback the interval is kept.
+	Feedback(Feedback)
+	// Interval returns the current interval.
+	Interval() time.Duration
+}
+
+type adaptiveTickerImpl struct {
+	*timeTickerImpl
+	min, max time.Duration
+	strategy Strategy
+
+	feedback atomic.Int32
+}
+
+var _ AdaptiveTicker = (*adaptiveTickerImpl)(nil)
+var _ Pausable = (*adaptiveTickerImpl)(nil)
+var _ Periodic = (*adaptiveTickerImpl)(nil)
+var _ Scheduled = (*adaptiveTickerImpl)(nil)
+
+// NewAdaptive creates a time ticker, that starts ticking with the min
+// interval, and adjusts the interval within [min, max] with the strategy on
+// the task feedback. The timer is started on the first call to Ticks, with an
+// immediate tick. The next tick comes the interval after the previous one is
+// processed by all the consumers. Use [WithFeedback] to report the task
+// results.
+//
+// Example:
+//
+//	t := ticker.NewAdaptive(time.Second, time.Minute, ticker.ExponentialStrategy(2))
+//	goticks.NewTask(t, ticker.WithFeedback(t, poll)).Start()
+func NewAdaptive(min, max time.Duration, strategy Strategy, opts ...TimerOption) AdaptiveTicker {
+	t := &adaptiveTickerImpl{
+		timeTickerImpl: NewTimer(min, opts...).(*timeTickerImpl),
+		min:            min,
+		max:            max,
+		strategy:       strategy,
+	}
+	t.feedback.Store(int32(noFeedback))
+	t.adjust = t.apply
+	return t
+}
+
+// Reset sets the current interval, clamped to the ticker bounds.
+// If d == 0, the ticker timer will be stopped. If called on a stopped
+// ticker with d != 0, the ticks are restarted.
+func (t *adaptiveTickerImpl) Reset(d time.Duration) {
+	if d != 0 {
+		d = t.clamp(d)
+	}
+	t.timeTickerImpl.Reset(d)
+}
+
+func (t *adaptiveTickerImpl) Feedback(f Feedback) {
+	t.feedback.Store(int32(f))
+}
+
+// Interval returns the current interval, same as [Periodic.Period].
+func (t *adaptiveTickerImpl) Interval() time.Duration {
+	return t.Period()
+}
+
+func (t *adaptiveTickerImpl) clamp(d time.Duration) time.Duration {
+	return min(max(d, t.min), t.max)
+}
+
+// apply returns the interval, adjusted with the last reported feedback, or 0
+// without feedback.
+func (t *adaptiveTickerImpl) apply() time.Duration {
+	f := Feedback(t.feedback.Swap(int32(noFeedback)))
+	if f == noFeedback {
+		return 0
+	}
+	return t.clamp(t.strategy(t.Interval(), f))
+}
+
+// WithFeedback wraps a task, that returns the feedback for the adaptive
+// ticker. The task errors are reported as [Failed].
+func WithFeedback(t AdaptiveTicker, task func(context.Context, time.Time) (Feedback, error)) func(context.Context, time.Time) error {
+	return func(ctx context.Context, tick time.Time) error {
+		f, err := task(ctx, tick)
+		if err != nil {
+			f = Failed
+		}
+		t.Feedback(f)
+		return err
+	}
+}
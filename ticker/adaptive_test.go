@@ -0,0 +1,74 @@
+package ticker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestNewAdaptive(t *testing.T) {
+	ticker := NewAdaptive(20*time.Millisecond, 80*time.Millisecond, ExponentialStrategy(2))
+	feedback := []Feedback{Idle, Idle, Failed, MoreWork, Idle}
+	var intervals []time.Duration
+	task := WithFeedback(ticker, func(_ context.Context, _ time.Time) (Feedback, error) {
+		f := feedback[len(intervals)]
+		intervals = append(intervals, ticker.Interval())
+		if f == Failed {
+			return MoreWork, errors.New("test")
+		}
+		return f, nil
+	})
+	var times []time.Time
+	for tick := range ticker.Ticks() {
+		times = append(times, tick)
+		_ = task(context.Background(), tick)
+		if len(intervals) == len(feedback) {
+			break
+		}
+	}
+	ticker.Stop()
+
+	assert.That(t,
+		assert.EqualSlices([]time.Duration{
+			20 * time.Millisecond,
+			40 * time.Millisecond,
+			80 * time.Millisecond,
+			80 * time.Millisecond,
+			20 * time.Millisecond,
+		}, intervals),
+		assert.Equal(80*time.Millisecond, times[3].Sub(times[2]).Round(10*time.Millisecond)))
+}
+
+func TestAdaptive_Reset(t *testing.T) {
+	ticker := NewAdaptive(20*time.Millisecond, 80*time.Millisecond, ExponentialStrategy(2))
+	ticker.Reset(time.Hour)
+	assert.That(t, assert.Equal(80*time.Millisecond, ticker.Interval()))
+	ticker.Reset(time.Millisecond)
+	assert.That(t, assert.Equal(20*time.Millisecond, ticker.Interval()))
+	ticker.Stop()
+}
+
+func TestAdaptive_Pause(t *testing.T) {
+	ticker := NewAdaptive(20*time.Millisecond, 80*time.Millisecond, ExponentialStrategy(2))
+	ticks := ToChan[time.Time](ticker, 0)
+	<-ticks
+	time.Sleep(5 * time.Millisecond)
+	next := ticker.(Scheduled).NextTick()
+	remaining := time.Until(next)
+	ticker.(Pausable).Pause()
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-ticks:
+		t.Error("unexpected tick while paused")
+	default:
+	}
+	ticker.(Pausable).Resume()
+	tick := <-ticks
+	ticker.Stop()
+	assert.That(t,
+		assert.True(remaining > 0 && remaining <= 20*time.Millisecond),
+		assert.True(tick.Sub(next) >= 45*time.Millisecond))
+}
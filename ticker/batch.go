@@ -0,0 +1,50 @@
+package ticker
+
+import "time"
+
+// Batch returns a ticker, that groups the source ticks, and dispatches a batch
+// when it reaches maxSize ticks, or when maxWait has passed since the first
+// tick of the batch. If maxWait is 0, the batches are only limited by size.
+// The remaining ticks are dispatched when the source ends or on Stop.
+//
+// Example:
+//
+//	// Insert the events in bulks of up to 100, at least once a second.
+//	goticks.NewTask(ticker.Batch(events, 100, time.Second), insertAll)
+func Batch[TickType any](src Tickable[TickType], maxSize int, maxWait time.Duration) Ticker[[]TickType] {
+	return relay(src, func(p *pumpTicker[[]TickType], in <-chan TickType, stop <-chan struct{}) {
+		var batch []TickType
+		timer := time.NewTimer(maxWait)
+		timer.Stop()
+		defer timer.Stop()
+
+		flush := func() {
+			timer.Stop()
+			if len(batch) > 0 {
+				p.Tick(batch).Wait()
+				batch = nil
+			}
+		}
+		for {
+			select {
+			case <-stop:
+				flush()
+				return
+			case tick, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, tick)
+				if len(batch) == 1 && maxWait > 0 {
+					timer.Reset(maxWait)
+				}
+				if maxSize > 0 && len(batch) >= maxSize {
+					flush()
+				}
+			case <-timer.C:
+				flush()
+			}
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestBatch(t *testing.T) {
+	t.Run("size and wait", func(t *testing.T) {
+		src := New[int]()
+		batched := Batch[int](src, 3, 50*time.Millisecond)
+		result := collect(batched)
+		for i := range 5 {
+			src.Tick(i).Wait()
+		}
+		time.Sleep(100 * time.Millisecond)
+		src.Tick(5).Wait()
+		batched.Stop()
+
+		batches := result()
+		assert.That(t,
+			assert.Equal(3, len(batches)),
+			assert.EqualSlices([]int{0, 1, 2}, batches[0]),
+			assert.EqualSlices([]int{3, 4}, batches[1]),
+			// Flushed on stop:
+			assert.EqualSlices([]int{5}, batches[2]))
+	})
+
+	t.Run("source end", func(t *testing.T) {
+		src := New[int]()
+		batched := Batch[int](src, 0, 0)
+		result := collect(batched)
+		for i := range 5 {
+			src.Tick(i).Wait()
+		}
+		src.Stop()
+		time.Sleep(50 * time.Millisecond)
+		batched.Stop()
+
+		batches := result()
+		assert.That(t,
+			assert.Equal(1, len(batches)),
+			assert.EqualSlices([]int{0, 1, 2, 3, 4}, batches[0]))
+	})
+}
@@ -0,0 +1,66 @@
+package ticker
+
+import "iter"
+
+// FromChan returns a ticker, that dispatches the values received from the
+// channel. The channel is read from the first call to Ticks or Start until
+// Stop, and the ticker is stopped when the channel is closed. A value is
+// considered received when the ticker consumers have processed it.
+func FromChan[TickType any](ch <-chan TickType) Ticker[TickType] {
+	p := &pumpTicker[TickType]{}
+	p.pump = func(stop <-chan struct{}) {
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				case tick, ok := <-ch:
+					if !ok {
+						p.end(stop)
+						return
+					}
+					p.Tick(tick).Wait()
+				}
+			}
+		}()
+	}
+	return p
+}
+
+// FromSeq returns a ticker, that dispatches the values of the sequence. The
+// sequence is iterated from the first call to Ticks or Start until Stop, and
+// the ticker is stopped when the sequence ends. Every value is dispatched
+// after the consumers have processed the previous one. Restarting the ticker
+// iterates the sequence again.
+func FromSeq[TickType any](seq iter.Seq[TickType]) Ticker[TickType] {
+	p := &pumpTicker[TickType]{}
+	p.pump = func(stop <-chan struct{}) {
+		go func() {
+			for tick := range seq {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				p.Tick(tick).Wait()
+			}
+			p.end(stop)
+		}()
+	}
+	return p
+}
+
+// ToChan subscribes to the ticker, and returns a channel with the given buffer
+// size, receiving the ticks. The channel is closed when the ticker stops. With
+// buffer > 0 a tick is acknowledged as soon as it is buffered.
+func ToChan[TickType any](t Tickable[TickType], buffer int) <-chan TickType {
+	ch := make(chan TickType, buffer)
+	ticks := t.Ticks()
+	go func() {
+		defer close(ch)
+		for tick := range ticks {
+			ch <- tick
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,83 @@
+package ticker
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int)
+	ticker := FromChan(ch)
+	result := collect(ticker)
+	for i := range 3 {
+		ch <- i
+	}
+	close(ch)
+	assert.That(t,
+		assert.EqualSlices([]int{0, 1, 2}, result()))
+}
+
+func TestFromSeq(t *testing.T) {
+	ticker := FromSeq(slices.Values([]int{0, 1, 2}))
+	var ticks []int
+	for tick := range ticker.Ticks() {
+		ticks = append(ticks, tick)
+	}
+	assert.That(t,
+		assert.EqualSlices([]int{0, 1, 2}, ticks))
+
+	// Restart iterates the sequence again.
+	result := collect(ticker)
+	assert.That(t,
+		assert.EqualSlices([]int{0, 1, 2}, result()))
+}
+
+func TestFromSeq_restart(t *testing.T) {
+	first, second := make(chan int), make(chan int)
+	var iterations atomic.Int32
+	ticker := FromSeq(func(yield func(int) bool) {
+		ch := first
+		if iterations.Add(1) > 1 {
+			ch = second
+		}
+		for tick := range ch {
+			if !yield(tick) {
+				return
+			}
+		}
+	})
+	ticker.(Restartable).Start()
+	for iterations.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ticker.Stop()
+	ticker.(Restartable).Start()
+	// The end of the first iteration does not stop the restarted ticker.
+	close(first)
+	time.Sleep(20 * time.Millisecond)
+	ticks := ToChan[int](ticker, 0)
+	second <- 1
+	assert.That(t, assert.Equal(1, <-ticks))
+	ticker.Stop()
+}
+
+func TestToChan(t *testing.T) {
+	ticker := New[int]()
+	ch := ToChan[int](ticker, 0)
+	go func() {
+		for i := range 3 {
+			ticker.Tick(i).Wait()
+		}
+		ticker.Stop()
+	}()
+	var ticks []int
+	for tick := range ch {
+		ticks = append(ticks, tick)
+	}
+	assert.That(t,
+		assert.EqualSlices([]int{0, 1, 2}, ticks))
+}
@@ -29,7 +29,8 @@ func (c *consumer[TickType]) send(tick TickType) {
 	select {
 	case <-c.doneCh:
 	case <-c.closeCh:
-		close(c.tickCh)
+		// The tick channel is not closed here, as the concurrent senders may
+		// still be sending to it. The reader returns on closeCh.
 	case c.tickCh <- tack:
 		<-tack.ackCh
 	}
@@ -41,6 +42,16 @@ func (c *consumer[TickType]) close() {
 	close(c.closeCh)
 }
 
+// done tells whether the reader has stopped consuming the ticks.
+func (c *consumer[TickType]) done() bool {
+	select {
+	case <-c.doneCh:
+		return true
+	default:
+		return false
+	}
+}
+
 // ticks returns an iterator that consumes all ticks and notifies the writer
 // when the tick is processed.
 func (c *consumer[TickType]) ticks() iter.Seq[TickType] {
@@ -48,11 +59,8 @@ func (c *consumer[TickType]) ticks() iter.Seq[TickType] {
 		defer close(c.doneCh)
 		for {
 			select {
-			case tickAck, ok := <-c.tickCh:
-				if !ok {
-					return
-				}
-				ok = yield(tickAck.tick)
+			case tickAck := <-c.tickCh:
+				ok := yield(tickAck.tick)
 				close(tickAck.ackCh)
 				if !ok {
 					return
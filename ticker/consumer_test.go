@@ -1,6 +1,7 @@
 package ticker
 
 import (
+	"sync"
 	"sync/atomic"
 	"testing"
 
@@ -40,6 +41,20 @@ func Test_consumer(t *testing.T) {
 		<-done
 	})
 
+	t.Run("concurrent sends on close", func(t *testing.T) {
+		c := newConsumer[int]()
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				c.send(0)
+				wg.Done()
+			}()
+		}
+		c.close()
+		wg.Wait()
+	})
+
 	t.Run("send after done", func(t *testing.T) {
 		c := newConsumer[int]()
 		go c.send(0)
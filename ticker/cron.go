@@ -0,0 +1,189 @@
+package ticker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule computes the times of the ticks.
+type Schedule interface {
+	// Next returns the first tick time strictly after t, or the zero time if
+	// there are no more ticks.
+	Next(t time.Time) time.Time
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 are Sunday.
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule keeps the allowed values of the fields as bit sets.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// anyDay is true if either the day of month or the day of week is not
+	// restricted, in which case both have to match. Otherwise, a day matches
+	// if any of them matches.
+	anyDay bool
+}
+
+var _ Schedule = (*cronSchedule)(nil)
+
+// ParseCron parses a standard 5-field cron expression: minute, hour, day of
+// month, month and day of week. The fields support '*', lists, ranges, steps,
+// and the English three-letter month and day names. The @yearly, @annually,
+// @monthly, @weekly, @daily, @midnight and @hourly macros are supported too.
+// The ticks are computed in the location of the time passed to Next.
+func ParseCron(expr string) (Schedule, error) {
+	if macro, ok := cronMacros[strings.ToLower(strings.TrimSpace(expr))]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	s := &cronSchedule{}
+	var err error
+	for i, f := range []struct {
+		field *cronField
+		bits  *uint64
+	}{
+		{&cronMinute, &s.minute},
+		{&cronHour, &s.hour},
+		{&cronDom, &s.dom},
+		{&cronMonth, &s.month},
+		{&cronDow, &s.dow},
+	} {
+		if *f.bits, err = f.field.parse(fields[i]); err != nil {
+			return nil, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.anyDay = strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+func (f *cronField) parse(expr string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		from, to, step := f.min, f.max, 1
+		rng, stepExpr, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step < 1 {
+				return 0, fmt.Errorf("bad %s step %q", f.name, stepExpr)
+			}
+		}
+		if rng != "*" {
+			fromExpr, toExpr, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = f.value(fromExpr); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if to, err = f.value(toExpr); err != nil {
+					return 0, err
+				}
+			case !hasStep:
+				to = from
+			}
+			if from > to {
+				return 0, fmt.Errorf("bad %s range %q", f.name, rng)
+			}
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func (f *cronField) value(expr string) (int, error) {
+	if v, ok := f.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("bad %s %q", f.name, expr)
+	}
+	return v, nil
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<t.Weekday()) != 0
+	if s.anyDay {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// There is a match within 5 years, unless the day never exists.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<t.Month()) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// NewCron creates a ticker, that ticks on the cron expression schedule.
+// See [ParseCron] and [NewSchedule].
+//
+// Example:
+//
+//	t, err := ticker.NewCron("30 9 * * mon-fri") // at 9:30 on weekdays
+func NewCron(expr string, opts ...TimerOption) (TimeTicker, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchedule(schedule, opts...), nil
+}
@@ -0,0 +1,62 @@
+package ticker
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{
+		"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "x * * * *",
+	} {
+		_, err := ParseCron(expr)
+		assert.That(t, assert.Not(assert.NoError(err)))
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	// Monday.
+	from := time.Date(2025, time.June, 2, 10, 17, 30, 0, time.UTC)
+	for expr, expected := range map[string]time.Time{
+		"* * * * *":              time.Date(2025, time.June, 2, 10, 18, 0, 0, time.UTC),
+		"*/15 * * * *":           time.Date(2025, time.June, 2, 10, 30, 0, 0, time.UTC),
+		"5/20 * * * *":           time.Date(2025, time.June, 2, 10, 25, 0, 0, time.UTC),
+		"0 9-17 * * *":           time.Date(2025, time.June, 2, 11, 0, 0, 0, time.UTC),
+		"30 9 * * mon-fri":       time.Date(2025, time.June, 3, 9, 30, 0, 0, time.UTC),
+		"0 0 * * 7":              time.Date(2025, time.June, 8, 0, 0, 0, 0, time.UTC),
+		"0 0 1,15 * *":           time.Date(2025, time.June, 15, 0, 0, 0, 0, time.UTC),
+		"0 0 13 * fri":           time.Date(2025, time.June, 6, 0, 0, 0, 0, time.UTC),
+		"0 0 29 feb *":           time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"@hourly":                time.Date(2025, time.June, 2, 11, 0, 0, 0, time.UTC),
+		"@monthly":               time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC),
+		"@YEARLY":                time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 30 2 *":             {},
+		"17,18 10 2 JUN mon-tue": time.Date(2025, time.June, 2, 10, 18, 0, 0, time.UTC),
+	} {
+		schedule, err := ParseCron(expr)
+		assert.That(t,
+			assert.NoError(err),
+			assert.True(expected.Equal(schedule.Next(from))))
+	}
+}
+
+type everyTicks time.Duration
+
+func (e everyTicks) Next(t time.Time) time.Time {
+	return t.Truncate(time.Duration(e)).Add(time.Duration(e))
+}
+
+func TestNewSchedule(t *testing.T) {
+	timer := NewSchedule(everyTicks(100 * time.Millisecond))
+	time.AfterFunc(350*time.Millisecond, timer.Stop)
+	times := slices.Collect(timer.Ticks())
+	assert.That(t, assert.True(len(times) >= 3))
+	for _, tick := range times {
+		assert.That(t, assert.Equal(time.Duration(0), tick.Sub(tick.Truncate(100*time.Millisecond)).Round(20*time.Millisecond)))
+	}
+	assert.That(t, assert.Equal(time.Duration(0), timer.(Periodic).Period()))
+}
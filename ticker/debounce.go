@@ -0,0 +1,72 @@
+package ticker
+
+import "time"
+
+type debounceOptions struct {
+	maxWait time.Duration
+}
+
+type DebounceOption func(*debounceOptions)
+
+// WithMaxWait limits the time a tick can be delayed by [Debounce] when the
+// source does not go quiet.
+func WithMaxWait(d time.Duration) DebounceOption {
+	return func(o *debounceOptions) {
+		o.maxWait = d
+	}
+}
+
+// Debounce returns a ticker, that dispatches the last source tick after the
+// source has been quiet for the given duration. The pending tick is dispatched
+// when the source ends.
+//
+// Example:
+//
+//	// Reload once after a burst of file events.
+//	goticks.NewTask(ticker.Debounce(fileEvents, 100*time.Millisecond), reload)
+func Debounce[TickType any](src Tickable[TickType], quiet time.Duration, opts ...DebounceOption) Ticker[TickType] {
+	var o debounceOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return relay(src, func(p *pumpTicker[TickType], in <-chan TickType, stop <-chan struct{}) {
+		var last TickType
+		var pending bool
+		quietTimer := time.NewTimer(quiet)
+		quietTimer.Stop()
+		maxTimer := time.NewTimer(o.maxWait)
+		maxTimer.Stop()
+		defer quietTimer.Stop()
+		defer maxTimer.Stop()
+
+		emit := func() {
+			quietTimer.Stop()
+			maxTimer.Stop()
+			pending = false
+			p.Tick(last).Wait()
+		}
+		for {
+			select {
+			case <-stop:
+				return
+			case tick, ok := <-in:
+				if !ok {
+					if pending {
+						emit()
+					}
+					return
+				}
+				last = tick
+				if !pending && o.maxWait > 0 {
+					maxTimer.Reset(o.maxWait)
+				}
+				pending = true
+				quietTimer.Reset(quiet)
+			case <-quietTimer.C:
+				emit()
+			case <-maxTimer.C:
+				emit()
+			}
+		}
+	})
+}
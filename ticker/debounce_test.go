@@ -0,0 +1,68 @@
+package ticker
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+// collect consumes the ticks in background, and returns the function that
+// waits for the ticks to end and returns them.
+func collect[TickType any](t Ticker[TickType]) func() []TickType {
+	ticks := t.Ticks()
+	result := make(chan []TickType)
+	go func() {
+		result <- slices.Collect(ticks)
+	}()
+	return func() []TickType {
+		return <-result
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	t.Run("burst", func(t *testing.T) {
+		src := New[int]()
+		debounced := Debounce[int](src, 50*time.Millisecond)
+		result := collect(debounced)
+		for i := range 5 {
+			src.Tick(i).Wait()
+		}
+		time.Sleep(100 * time.Millisecond)
+		src.Tick(10).Wait()
+		time.Sleep(100 * time.Millisecond)
+		debounced.Stop()
+		assert.That(t,
+			assert.EqualSlices([]int{4, 10}, result()))
+	})
+
+	t.Run("max wait", func(t *testing.T) {
+		src := New[int]()
+		debounced := Debounce[int](src, 50*time.Millisecond, WithMaxWait(70*time.Millisecond))
+		result := collect(debounced)
+		for i := range 10 {
+			src.Tick(i).Wait()
+			time.Sleep(20 * time.Millisecond)
+		}
+		time.Sleep(100 * time.Millisecond)
+		debounced.Stop()
+		ticks := result()
+		assert.That(t,
+			assert.True(len(ticks) > 1),
+			assert.Equal(9, ticks[len(ticks)-1]))
+	})
+
+	t.Run("flush on source end", func(t *testing.T) {
+		src := New[int]()
+		debounced := Debounce[int](src, time.Hour)
+		result := collect(debounced)
+		src.Tick(1).Wait()
+		src.Stop()
+		// The pending tick is dispatched when the source ends.
+		time.Sleep(50 * time.Millisecond)
+		debounced.Stop()
+		assert.That(t,
+			assert.EqualSlices([]int{1}, result()))
+	})
+}
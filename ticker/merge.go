@@ -0,0 +1,94 @@
+package ticker
+
+import "iter"
+
+// Tagged is a tick value with the index of the source ticker.
+type Tagged[TickType any] struct {
+	Source int
+	Value  TickType
+}
+
+// Merge returns a ticker, that dispatches the ticks of all the source tickers.
+// A source tick is acknowledged when the merged ticker consumers have
+// processed it, so that waiting on the source reflects the completion.
+//
+// The sources are subscribed on the first call to Ticks or on Start. Start
+// and Stop are propagated to the sources, that implement [Restartable].
+func Merge[TickType any](tickers ...Tickable[TickType]) Ticker[TickType] {
+	return mergeMap(tickers, func(_ int, tick TickType) TickType {
+		return tick
+	})
+}
+
+// MergeTagged is the [Merge] variant, that tags the ticks with the index of
+// the source. Use [AsAny] to merge tickers of different tick types.
+//
+// Example:
+//
+//	ticker.MergeTagged(ticker.AsAny(ticker.NewTimer(time.Hour)), ticker.AsAny(configEvents))
+func MergeTagged[TickType any](tickers ...Tickable[TickType]) Ticker[Tagged[TickType]] {
+	return mergeMap(tickers, func(i int, tick TickType) Tagged[TickType] {
+		return Tagged[TickType]{i, tick}
+	})
+}
+
+func mergeMap[T, TickType any](tickers []Tickable[T], convert func(int, T) TickType) Ticker[TickType] {
+	p := &pumpTicker[TickType]{}
+	p.pump = func(stop <-chan struct{}) {
+		for i, src := range tickers {
+			ticks := src.Ticks()
+			if r, ok := src.(Restartable); ok {
+				r.Start()
+			}
+			go forward(ticks, stop, p.Tick, func(tick T) TickType {
+				return convert(i, tick)
+			})
+		}
+	}
+	p.onStop = func() {
+		for _, src := range tickers {
+			if r, ok := src.(Restartable); ok {
+				r.Stop()
+			}
+		}
+	}
+	return p
+}
+
+type anyTicker[TickType any] struct {
+	Tickable[TickType]
+}
+
+// AsAny converts the ticker to a ticker of any ticks. Start and Stop are
+// forwarded to the ticker, if it implements them.
+// Ticking it with a value of a wrong type panics.
+func AsAny[TickType any](t Tickable[TickType]) Tickable[any] {
+	return anyTicker[TickType]{t}
+}
+
+func (a anyTicker[TickType]) Ticks() iter.Seq[any] {
+	ticks := a.Tickable.Ticks()
+	return func(yield func(any) bool) {
+		for tick := range ticks {
+			if !yield(tick) {
+				return
+			}
+		}
+	}
+}
+
+func (a anyTicker[TickType]) Tick(tick any) Waitable {
+	return a.Tickable.Tick(tick.(TickType))
+}
+
+func (a anyTicker[TickType]) Start() {
+	if r, ok := a.Tickable.(Startable); ok {
+		r.Start()
+	}
+}
+
+func (a anyTicker[TickType]) Stop() {
+	if r, ok := a.Tickable.(Stoppable); ok {
+		r.Stop()
+	}
+}
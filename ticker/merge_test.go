@@ -0,0 +1,64 @@
+package ticker
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("ack", func(t *testing.T) {
+		a, b := New[int](), New[int]()
+		merged := Merge[int](a, b)
+
+		var mux sync.Mutex
+		var got []int
+		done := make(chan struct{})
+		ticks := merged.Ticks()
+		go func() {
+			for tick := range ticks {
+				mux.Lock()
+				got = append(got, tick)
+				mux.Unlock()
+			}
+			close(done)
+		}()
+		a.Tick(1).Wait()
+		b.Tick(2).Wait()
+		a.Tick(3).Wait()
+		merged.Tick(4).Wait()
+		merged.Stop()
+		<-done
+		assert.That(t,
+			assert.EqualSlices([]int{1, 2, 3, 4}, got))
+	})
+
+	t.Run("tagged restartable", func(t *testing.T) {
+		timer := NewTimer(time.Hour)
+		events := New[string]()
+		merged := MergeTagged(AsAny(timer), AsAny[string](events))
+
+		gotCh := make(chan Tagged[any])
+		ticks := merged.Ticks()
+		go func() {
+			for tick := range ticks {
+				gotCh <- tick
+			}
+			close(gotCh)
+		}()
+		first := <-gotCh
+		wg := events.Tick("config")
+		second := <-gotCh
+		wg.Wait()
+		merged.Stop()
+		_, open := <-gotCh
+
+		assert.That(t,
+			assert.Equal(0, first.Source),
+			assert.Equal(Tagged[any]{1, "config"}, second),
+			assert.False(open),
+			assert.False(timer.(*timeTickerImpl).running.Load()))
+	})
+}
@@ -0,0 +1,129 @@
+package ticker
+
+import (
+	"iter"
+	"sync"
+)
+
+// pumpTicker dispatches to its consumers the ticks, pumped from an upstream
+// source. The pump is started on the first call to Ticks or on Start, and is
+// signalled to stop on Stop.
+type pumpTicker[TickType any] struct {
+	tickerImpl[TickType]
+	// pump subscribes to the upstream, and starts the goroutines, that
+	// dispatch the ticks until the stop channel is closed.
+	pump func(stop <-chan struct{})
+	// onStop is called on Stop before the consumers are closed.
+	onStop func()
+
+	mux    sync.Mutex
+	stopCh chan struct{}
+	// processWg tracks the relay process goroutines, so that they could
+	// dispatch the pending ticks on Stop before the consumers are closed.
+	processWg sync.WaitGroup
+}
+
+var _ Ticker[any] = (*pumpTicker[any])(nil)
+var _ Restartable = (*pumpTicker[any])(nil)
+
+func (p *pumpTicker[TickType]) Ticks() iter.Seq[TickType] {
+	defer p.Start()
+	return p.tickerImpl.Ticks()
+}
+
+// Start the pump, if it is not yet running.
+func (p *pumpTicker[TickType]) Start() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if p.stopCh != nil {
+		return
+	}
+	p.stopCh = make(chan struct{})
+	p.pump(p.stopCh)
+}
+
+// Stop the pump and terminate consumers.
+func (p *pumpTicker[TickType]) Stop() {
+	p.mux.Lock()
+	if p.stopCh != nil {
+		close(p.stopCh)
+		p.stopCh = nil
+	}
+	p.mux.Unlock()
+	p.terminate()
+}
+
+// end stops the ticker, when the upstream of the pump, started with the stop
+// channel, ends. It does nothing, if the ticker has been stopped or restarted
+// since.
+func (p *pumpTicker[TickType]) end(stop <-chan struct{}) {
+	p.mux.Lock()
+	if p.stopCh != stop {
+		p.mux.Unlock()
+		return
+	}
+	close(p.stopCh)
+	p.stopCh = nil
+	p.mux.Unlock()
+	p.terminate()
+}
+
+// terminate waits for the process goroutines, and terminates consumers.
+func (p *pumpTicker[TickType]) terminate() {
+	p.processWg.Wait()
+	if p.onStop != nil {
+		p.onStop()
+	}
+	p.tickerImpl.Stop()
+}
+
+// forward dispatches the converted upstream ticks, waiting for the consumers
+// to process every tick, until the upstream ends or stop is closed.
+func forward[T, TickType any](ticks iter.Seq[T], stop <-chan struct{}, tick func(TickType) Waitable, convert func(T) TickType) {
+	for t := range ticks {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		tick(convert(t)).Wait()
+	}
+}
+
+// relay returns a ticker, which pump subscribes to the source and runs process
+// in a goroutine on the channel of the source ticks. The source ticks are
+// acknowledged as soon as process receives them. The channel is closed when
+// the source ends. Stop waits for process to return before closing the
+// consumers. Start and Stop are propagated to the source, if it
+// implements [Restartable].
+func relay[T, TickType any](src Tickable[T], process func(p *pumpTicker[TickType], in <-chan T, stop <-chan struct{})) *pumpTicker[TickType] {
+	p := &pumpTicker[TickType]{}
+	p.pump = func(stop <-chan struct{}) {
+		ticks := src.Ticks()
+		if r, ok := src.(Restartable); ok {
+			r.Start()
+		}
+		in := make(chan T)
+		go func() {
+			defer close(in)
+			for tick := range ticks {
+				select {
+				case <-stop:
+					return
+				case in <- tick:
+				}
+			}
+		}()
+		p.processWg.Add(1)
+		go func() {
+			defer p.processWg.Done()
+			process(p, in, stop)
+		}()
+	}
+	p.onStop = func() {
+		if r, ok := src.(Restartable); ok {
+			r.Stop()
+		}
+	}
+	return p
+}
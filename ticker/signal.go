@@ -0,0 +1,40 @@
+package ticker
+
+import (
+	"os"
+	"os/signal"
+)
+
+// NewSignal returns a ticker, that dispatches the received OS signals. The
+// signals are subscribed with [signal.Notify] from the first call to Ticks or
+// Start, and unsubscribed on Stop. A signal, that is received while the
+// consumers process the previous one, is buffered, and the following ones are
+// dropped. The signal, buffered on Stop, is not dispatched after a restart.
+//
+// Example:
+//
+//	t := ticker.NewSignal(syscall.SIGHUP)
+//	goticks.NewTask(t, reload).Start()
+func NewSignal(sigs ...os.Signal) Ticker[os.Signal] {
+	ch := make(chan os.Signal, 1)
+	p := &pumpTicker[os.Signal]{onStop: func() { signal.Stop(ch) }}
+	p.pump = func(stop <-chan struct{}) {
+		// Drop the signal, buffered before the previous Stop.
+		select {
+		case <-ch:
+		default:
+		}
+		signal.Notify(ch, sigs...)
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				case sig := <-ch:
+					p.Tick(sig).Wait()
+				}
+			}
+		}()
+	}
+	return p
+}
@@ -0,0 +1,68 @@
+//go:build linux
+
+package ticker
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestNewSignal(t *testing.T) {
+	// Keeps the process alive on the signals after the ticker stops.
+	guard := make(chan os.Signal, 1)
+	signal.Notify(guard, syscall.SIGUSR1)
+	defer signal.Stop(guard)
+
+	ticker := NewSignal(syscall.SIGUSR1, syscall.SIGUSR2)
+	ch := ToChan(ticker, 0)
+	_ = syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	assert.That(t, assert.Equal[os.Signal](syscall.SIGUSR1, <-ch))
+	<-guard
+
+	ticker.Stop()
+	_, ok := <-ch
+	assert.That(t, assert.False(ok))
+
+	_ = syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	select {
+	case <-guard:
+	case <-time.After(time.Second):
+		t.Error("the guard has not received the signal")
+	}
+	// The signals, received while stopped, are not dispatched on restart.
+	ch = ToChan(ticker, 1)
+	select {
+	case sig := <-ch:
+		t.Error("unexpected signal", sig)
+	case <-time.After(50 * time.Millisecond):
+	}
+	_ = syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+	assert.That(t, assert.Equal[os.Signal](syscall.SIGUSR2, <-ch))
+	ticker.Stop()
+}
+
+func TestNewSignal_buffered(t *testing.T) {
+	ticker := NewSignal(syscall.SIGUSR2)
+	ticks := ticker.Ticks()
+	// The consumer is not reading, so the second signal stays buffered.
+	for range 2 {
+		_ = syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+		time.Sleep(20 * time.Millisecond)
+	}
+	ticker.Stop()
+	for range ticks {
+	}
+
+	ch := ToChan(ticker, 1)
+	select {
+	case sig := <-ch:
+		t.Error("unexpected signal", sig)
+	case <-time.After(50 * time.Millisecond):
+	}
+	ticker.Stop()
+}
@@ -0,0 +1,63 @@
+package ticker
+
+import "time"
+
+// Edge selects the ticks of a throttling window, dispatched by [Throttle].
+type Edge int
+
+const (
+	// Leading dispatches the first tick of a window.
+	Leading Edge = 1 << iota
+	// Trailing dispatches the last tick received during a window at its end.
+	Trailing
+)
+
+// Throttle returns a ticker, that dispatches at most one source tick per
+// interval. The edge selects whether the first tick of a window, the last one,
+// or both are dispatched. If edge is 0, [Leading] is used. A pending trailing
+// tick is dispatched when the source ends.
+func Throttle[TickType any](src Tickable[TickType], interval time.Duration, edge Edge) Ticker[TickType] {
+	if edge == 0 {
+		edge = Leading
+	}
+	return relay(src, func(p *pumpTicker[TickType], in <-chan TickType, stop <-chan struct{}) {
+		var last TickType
+		var pending, window bool
+		timer := time.NewTimer(interval)
+		timer.Stop()
+		defer timer.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case tick, ok := <-in:
+				if !ok {
+					if pending {
+						p.Tick(last).Wait()
+					}
+					return
+				}
+				if !window {
+					window = true
+					timer.Reset(interval)
+					if edge&Leading != 0 {
+						p.Tick(tick).Wait()
+						continue
+					}
+				}
+				if edge&Trailing != 0 {
+					last = tick
+					pending = true
+				}
+			case <-timer.C:
+				window = false
+				if pending {
+					pending = false
+					window = true
+					timer.Reset(interval)
+					p.Tick(last).Wait()
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestThrottle(t *testing.T) {
+	for name, test := range map[string]struct {
+		edge     Edge
+		expected []int
+	}{
+		"leading":  {Leading, []int{0, 10}},
+		"trailing": {Trailing, []int{4, 10}},
+		"both":     {Leading | Trailing, []int{0, 4, 10}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			src := New[int]()
+			throttled := Throttle[int](src, 50*time.Millisecond, test.edge)
+			result := collect(throttled)
+			for i := range 5 {
+				src.Tick(i).Wait()
+			}
+			time.Sleep(150 * time.Millisecond)
+			src.Tick(10).Wait()
+			time.Sleep(100 * time.Millisecond)
+			throttled.Stop()
+			assert.That(t,
+				assert.EqualSlices(test.expected, result()))
+		})
+	}
+}
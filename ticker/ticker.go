@@ -24,10 +24,37 @@ type Restartable interface {
 	Stoppable
 }
 
+// Pausable is implemented by the tickers, that can suspend the tick
+// dispatching without terminating the consumers.
+type Pausable interface {
+	Pause()
+	Resume()
+	Paused() bool
+}
+
+// Periodic is implemented by the tickers, that tick with a period.
+type Periodic interface {
+	Period() time.Duration
+}
+
+// Scheduled is implemented by the tickers, that know the time of the next
+// tick.
+type Scheduled interface {
+	// NextTick returns the time of the next tick, or the zero time if no tick
+	// is scheduled.
+	NextTick() time.Time
+}
+
 type Waitable interface {
 	Wait()
 }
 
+// Observable is implemented by the tickers, that report the number of their
+// active consumers.
+type Observable interface {
+	Consumers() int
+}
+
 type Ticker[TickType any] interface {
 	Tickable[TickType]
 	Stoppable
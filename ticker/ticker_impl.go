@@ -14,6 +14,7 @@ type tickerImpl[TickType any] struct {
 }
 
 var _ Ticker[any] = (*tickerImpl[any])(nil)
+var _ Observable = (*tickerImpl[any])(nil)
 
 func New[TickType any]() Ticker[TickType] {
 	return &tickerImpl[TickType]{}
@@ -59,6 +60,18 @@ func (t *tickerImpl[TickType]) Ticks() iter.Seq[TickType] {
 	return consumer.ticks()
 }
 
+// Consumers returns the number of consumers, that have not yet finished
+// iterating over the ticks.
+func (t *tickerImpl[TickType]) Consumers() int {
+	n := 0
+	t.forEach(func(_ int64, consumer *consumer[TickType]) {
+		if !consumer.done() {
+			n++
+		}
+	})
+	return n
+}
+
 // Wait for the consumers to finish processing the current tick.
 func (t *tickerImpl[TickType]) Wait() {
 	t.wg.Wait()
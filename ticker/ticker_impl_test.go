@@ -27,3 +27,33 @@ func TestNew(t *testing.T) {
 		}
 	})
 }
+
+func TestTicker_Consumers(t *testing.T) {
+	ticker := New[int]()
+	observable := ticker.(Observable)
+	ticks := ticker.Ticks()
+	drain := ticker.Ticks()
+	if n := observable.Consumers(); n != 2 {
+		t.Errorf("expected %d consumers, got %d", 2, n)
+	}
+	go func() {
+		for range drain {
+		}
+	}()
+	done := make(chan struct{})
+	go func() {
+		for range ticks {
+			break
+		}
+		close(done)
+	}()
+	ticker.Tick(1).Wait()
+	<-done
+	if n := observable.Consumers(); n != 1 {
+		t.Errorf("expected %d consumers, got %d", 1, n)
+	}
+	ticker.Stop()
+	if n := observable.Consumers(); n != 0 {
+		t.Errorf("expected %d consumers, got %d", 0, n)
+	}
+}
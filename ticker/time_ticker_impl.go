@@ -2,34 +2,142 @@ package ticker
 
 import (
 	"iter"
+	"math/rand/v2"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+type timerOptions struct {
+	initialDelay time.Duration
+	fireMissed   bool
+	jitter       time.Duration
+	aligned      bool
+	offset       time.Duration
+}
+
+type TimerOption func(*timerOptions)
+
+// WithInitialDelay delays the first tick of a periodic time ticker after every
+// start, instead of ticking immediately.
+func WithInitialDelay(d time.Duration) TimerOption {
+	return func(o *timerOptions) {
+		o.initialDelay = d
+	}
+}
+
+// WithFireMissed makes [Pausable.Resume] dispatch a tick immediately if it was
+// due during the pause, and keep the phase of the ticks as if there were no
+// pause. Without the option, the ticks resume after the time, that remained
+// until the next tick on [Pausable.Pause].
+func WithFireMissed() TimerOption {
+	return func(o *timerOptions) {
+		o.fireMissed = true
+	}
+}
+
+// WithJitter delays every tick by a random duration in [0, max), without
+// changing the phase of the following ticks. The max should be less than the
+// period.
+func WithJitter(max time.Duration) TimerOption {
+	return func(o *timerOptions) {
+		o.jitter = max
+	}
+}
+
+// WithAlignment aligns the ticks of a periodic time ticker to the multiples of
+// the period since the zero time, shifted by the offset. For the periods, that
+// divide a day, this is the wall clock in UTC, e.g. every full hour for an
+// hour period. The first tick is dispatched on the first aligned time after
+// the start, instead of immediately.
+func WithAlignment(offset time.Duration) TimerOption {
+	return func(o *timerOptions) {
+		o.aligned = true
+		o.offset = offset
+	}
+}
+
 type timeTickerImpl struct {
 	tickerImpl[time.Time]
 	resetCh  chan time.Duration
 	duration atomic.Int64
+	options  timerOptions
+
+	// oneShot tickers stop ticking after the first tick.
+	oneShot bool
+	// at is the Unix time in nanoseconds of the first tick of a one-shot
+	// ticker, if set.
+	at atomic.Int64
+
+	// schedule, if set, computes the tick times instead of the period.
+	schedule Schedule
+
+	// adjust, if set, is called when a tick has been processed by all the
+	// consumers, and returns the new period, or 0 to keep the period. The next
+	// tick is scheduled a period after the processing.
+	adjust func() time.Duration
+
+	// paused is the requested pause state, signalled to the run loop via
+	// pauseCh.
+	paused  atomic.Bool
+	pauseCh chan struct{}
+
+	// nextTick is the Unix time in nanoseconds of the next tick, or 0.
+	nextTick atomic.Int64
 
 	running atomic.Bool
 	runWg   sync.WaitGroup
 }
 
 var _ TimeTicker = (*timeTickerImpl)(nil)
+var _ Pausable = (*timeTickerImpl)(nil)
+var _ Periodic = (*timeTickerImpl)(nil)
+var _ Scheduled = (*timeTickerImpl)(nil)
 
 // NewTimer creates a ticker that ticks on a timer.
 // The timer is started on the first call to Ticks.
 // If d == 0, the ticker internal timer is not started, and no ticks are
 // dispatched.
-func NewTimer(d time.Duration) TimeTicker {
+func NewTimer(d time.Duration, opts ...TimerOption) TimeTicker {
 	t := &timeTickerImpl{
 		resetCh: make(chan time.Duration),
+		pauseCh: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(&t.options)
 	}
 	t.duration.Store(int64(d))
 	return t
 }
 
+// NewSchedule creates a ticker that ticks on the times computed by the
+// schedule. The timer is started on the first call to Ticks. [TimeTicker.Reset]
+// with d != 0 restarts the ticks from the next scheduled time, ignoring d.
+func NewSchedule(schedule Schedule, opts ...TimerOption) TimeTicker {
+	t := NewTimer(0, opts...).(*timeTickerImpl)
+	t.schedule = schedule
+	return t
+}
+
+// NewAfter creates a ticker that ticks once, d after it is started.
+// The timer is started on the first call to Ticks. [TimeTicker.Reset]
+// reschedules the tick, or schedules another one if it has already been
+// dispatched. Stop cancels the pending tick.
+func NewAfter(d time.Duration) TimeTicker {
+	t := NewTimer(d).(*timeTickerImpl)
+	t.oneShot = true
+	return t
+}
+
+// NewAt creates a ticker that ticks once at the given time, or immediately if
+// the time has passed by the start. [TimeTicker.Reset] reschedules the tick
+// after the given duration.
+func NewAt(at time.Time) TimeTicker {
+	t := NewAfter(0).(*timeTickerImpl)
+	t.at.Store(at.UnixNano())
+	return t
+}
+
 func (t *timeTickerImpl) Ticks() iter.Seq[time.Time] {
 	defer t.Start()
 	return t.tickerImpl.Ticks()
@@ -44,19 +152,48 @@ func (t *timeTickerImpl) Start() {
 	}
 }
 
-// Stop stops the timer and terminates consumers.
+// Stop stops the timer and terminates consumers. The pause is cancelled.
 func (t *timeTickerImpl) Stop() {
+	t.paused.Store(false)
 	t.Reset(0)
 	t.tickerImpl.Stop()
 }
 
+// Pause suspends the tick dispatching without terminating the consumers.
+// A ticker, started while paused, does not tick until resumed.
+func (t *timeTickerImpl) Pause() {
+	t.setPaused(true)
+}
+
+// Resume resumes the tick dispatching, suspended by [Pause].
+func (t *timeTickerImpl) Resume() {
+	t.setPaused(false)
+}
+
+// Paused reports whether the ticker is paused.
+func (t *timeTickerImpl) Paused() bool {
+	return t.paused.Load()
+}
+
+func (t *timeTickerImpl) setPaused(paused bool) {
+	if t.paused.Swap(paused) != paused {
+		select {
+		case t.pauseCh <- struct{}{}:
+		default:
+			// The run loop has not yet handled the previous signal, and will
+			// read the new state.
+		}
+	}
+}
+
 // Reset changes the period of the currently running and future ticks.
 // If d == 0, the ticker timer will be stopped. If called on a stopped
 // ticker with d != 0, the ticks are restarted.
 func (t *timeTickerImpl) Reset(d time.Duration) {
-	if d != 0 {
+	if d != 0 && t.schedule == nil {
 		// Do not store 0, so that [Start] starts normally.
 		t.duration.Store(int64(d))
+		t.at.Store(0)
 	}
 	select {
 	case t.resetCh <- d:
@@ -68,29 +205,177 @@ func (t *timeTickerImpl) Reset(d time.Duration) {
 	}
 }
 
+// Period returns the last non-zero period of the ticker, or 0 for the
+// schedule and the one-shot tickers.
+func (t *timeTickerImpl) Period() time.Duration {
+	if t.oneShot {
+		return 0
+	}
+	return time.Duration(t.duration.Load())
+}
+
+// NextTick returns the time of the next tick, or the zero time if the ticker
+// is stopped or paused.
+func (t *timeTickerImpl) NextTick() time.Time {
+	if next := t.nextTick.Load(); next != 0 {
+		return time.Unix(0, next)
+	}
+	return time.Time{}
+}
+
+// first returns the time of the first tick after the start at now.
+func (t *timeTickerImpl) first(now time.Time, d time.Duration) time.Time {
+	switch {
+	case t.schedule != nil:
+		return t.schedule.Next(now.Add(t.options.initialDelay))
+	case t.oneShot:
+		if at := t.at.Load(); at != 0 {
+			return time.Unix(0, at)
+		}
+		return now.Add(d)
+	case t.options.aligned:
+		return t.align(now.Add(t.options.initialDelay), d)
+	}
+	return now.Add(t.options.initialDelay)
+}
+
+// after returns the time of the next tick after a reset at now.
+func (t *timeTickerImpl) after(now time.Time, d time.Duration) time.Time {
+	switch {
+	case t.schedule != nil:
+		return t.schedule.Next(now)
+	case t.options.aligned && !t.oneShot:
+		return t.align(now, d)
+	}
+	return now.Add(d)
+}
+
+// align returns the first aligned time after now.
+func (t *timeTickerImpl) align(now time.Time, d time.Duration) time.Time {
+	next := now.Add(-t.options.offset).Truncate(d).Add(t.options.offset)
+	for !next.After(now) {
+		next = next.Add(d)
+	}
+	return next
+}
+
+// delay returns the timer duration until the next tick, with the jitter.
+func (t *timeTickerImpl) delay(next time.Time) time.Duration {
+	delay := time.Until(next)
+	if t.options.jitter > 0 {
+		delay += rand.N(t.options.jitter)
+	}
+	return delay
+}
+
 func (t *timeTickerImpl) run() {
 	defer t.running.Store(false)
 	defer t.runWg.Done()
 	d := time.Duration(t.duration.Load())
-	if d == 0 {
+	if d == 0 && t.at.Load() == 0 && t.schedule == nil {
 		return
 	}
-	t.Tick(time.Now())
-
-	timer := time.NewTicker(d)
+	defer t.nextTick.Store(0)
+	next := t.first(time.Now(), d)
+	timer := time.NewTimer(0)
 	defer timer.Stop()
+	// arm sets the timer to the next tick. A schedule may have no more ticks.
+	arm := func() {
+		if next.IsZero() {
+			timer.Stop()
+			t.nextTick.Store(0)
+			return
+		}
+		delay := t.delay(next)
+		t.nextTick.Store(time.Now().Add(delay).UnixNano())
+		timer.Reset(delay)
+	}
+	var pausedAt time.Time
+	if t.paused.Load() {
+		timer.Stop()
+		pausedAt = time.Now()
+	} else {
+		arm()
+	}
+	// skip moves next to the first tick after now, keeping the phase.
+	skip := func(now time.Time) {
+		if t.schedule != nil {
+			next = t.schedule.Next(now)
+			return
+		}
+		for next = next.Add(d); !next.After(now); next = next.Add(d) {
+		}
+	}
+	// processed is closed when the last tick is processed, if adjust is set.
+	var processed chan struct{}
 	for {
 		select {
-		case tick, ok := <-timer.C:
-			if !ok {
+		case tick := <-timer.C:
+			waitable := t.Tick(tick)
+			if t.oneShot {
 				return
 			}
-			t.Tick(tick)
-		case d := <-t.resetCh:
+			if t.adjust != nil {
+				t.nextTick.Store(0)
+				ch := make(chan struct{})
+				go func() {
+					waitable.Wait()
+					close(ch)
+				}()
+				processed = ch
+				continue
+			}
+			skip(tick)
+			arm()
+		case <-processed:
+			processed = nil
+			if adjusted := t.adjust(); adjusted != 0 {
+				d = adjusted
+				t.duration.Store(int64(d))
+			}
+			next = t.after(time.Now(), d)
+			if pausedAt.IsZero() {
+				arm()
+			} else {
+				pausedAt = time.Now()
+			}
+		case d = <-t.resetCh:
 			if d == 0 {
 				return
 			}
-			timer.Reset(time.Duration(d))
+			// The reset overrides the adjustment of the tick in processing.
+			processed = nil
+			next = t.after(time.Now(), d)
+			if pausedAt.IsZero() {
+				arm()
+			} else {
+				pausedAt = time.Now()
+			}
+		case <-t.pauseCh:
+			now := time.Now()
+			switch paused := t.paused.Load(); {
+			case paused && pausedAt.IsZero():
+				timer.Stop()
+				t.nextTick.Store(0)
+				pausedAt = now
+			case !paused && !pausedAt.IsZero() && processed != nil:
+				// The next tick is scheduled when the processing ends.
+				pausedAt = time.Time{}
+			case !paused && !pausedAt.IsZero():
+				switch {
+				case next.IsZero():
+				case !t.options.fireMissed:
+					next = now.Add(next.Sub(pausedAt))
+				case !next.After(now):
+					t.Tick(now)
+					if t.oneShot {
+						return
+					}
+					skip(now)
+				}
+				pausedAt = time.Time{}
+				arm()
+			}
 		}
 	}
 }
@@ -42,3 +42,159 @@ func TestNewTimer(t *testing.T) {
 		t.Errorf("i expected to be %d, got %d", 3, len(times))
 	}
 }
+
+func TestWithInitialDelay(t *testing.T) {
+	timer := NewTimer(100*time.Millisecond, WithInitialDelay(250*time.Millisecond))
+	start := time.Now()
+	time.AfterFunc(500*time.Millisecond, timer.Stop)
+	times := slices.Collect(timer.Ticks())
+	assert.That(t,
+		assert.Equal(3, len(times)),
+		assert.Equal(250*time.Millisecond, times[0].Sub(start).Round(50*time.Millisecond)),
+		assert.Equal(100*time.Millisecond, times[1].Sub(times[0]).Round(50*time.Millisecond)))
+}
+
+func TestNewAfter(t *testing.T) {
+	t.Run("once", func(t *testing.T) {
+		timer := NewAfter(100 * time.Millisecond)
+		start := time.Now()
+		time.AfterFunc(400*time.Millisecond, timer.Stop)
+		times := slices.Collect(timer.Ticks())
+		assert.That(t,
+			assert.Equal(1, len(times)),
+			assert.Equal(100*time.Millisecond, times[0].Sub(start).Round(50*time.Millisecond)))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		timer := NewAfter(100 * time.Millisecond)
+		start := time.Now()
+		ticks := timer.Ticks()
+		time.AfterFunc(50*time.Millisecond, func() {
+			// Reschedule the pending tick.
+			timer.Reset(200 * time.Millisecond)
+		})
+		time.AfterFunc(600*time.Millisecond, timer.Stop)
+		var times []time.Time
+		for tick := range ticks {
+			times = append(times, tick)
+			if len(times) == 1 {
+				// Schedule another tick.
+				go timer.Reset(100 * time.Millisecond)
+			}
+		}
+		assert.That(t,
+			assert.Equal(2, len(times)),
+			assert.Equal(250*time.Millisecond, times[0].Sub(start).Round(50*time.Millisecond)),
+			assert.Equal(100*time.Millisecond, times[1].Sub(times[0]).Round(50*time.Millisecond)))
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		timer := NewAfter(100 * time.Millisecond)
+		time.AfterFunc(50*time.Millisecond, timer.Stop)
+		assert.That(t,
+			assert.Equal(0, len(slices.Collect(timer.Ticks()))))
+	})
+}
+
+func TestNewAt(t *testing.T) {
+	start := time.Now()
+	timer := NewAt(start.Add(150 * time.Millisecond))
+	time.AfterFunc(400*time.Millisecond, timer.Stop)
+	times := slices.Collect(timer.Ticks())
+	assert.That(t,
+		assert.Equal(1, len(times)),
+		assert.Equal(150*time.Millisecond, times[0].Sub(start).Round(50*time.Millisecond)))
+}
+
+func TestTicker_Pause(t *testing.T) {
+	round := func(d time.Duration) time.Duration {
+		return d.Round(25 * time.Millisecond)
+	}
+	t.Run("resume with remaining time", func(t *testing.T) {
+		timer := NewTimer(100 * time.Millisecond)
+		start := time.Now()
+		time.AfterFunc(50*time.Millisecond, timer.(Pausable).Pause)
+		time.AfterFunc(250*time.Millisecond, timer.(Pausable).Resume)
+		time.AfterFunc(425*time.Millisecond, timer.Stop)
+		times := slices.Collect(timer.Ticks())
+		assert.That(t,
+			assert.Equal(3, len(times)),
+			assert.Equal(0, round(times[0].Sub(start))),
+			assert.Equal(300*time.Millisecond, round(times[1].Sub(start))),
+			assert.Equal(100*time.Millisecond, round(times[2].Sub(times[1]))))
+	})
+
+	t.Run("fire missed", func(t *testing.T) {
+		timer := NewTimer(100*time.Millisecond, WithFireMissed())
+		start := time.Now()
+		time.AfterFunc(50*time.Millisecond, timer.(Pausable).Pause)
+		time.AfterFunc(250*time.Millisecond, timer.(Pausable).Resume)
+		time.AfterFunc(375*time.Millisecond, timer.Stop)
+		times := slices.Collect(timer.Ticks())
+		assert.That(t,
+			assert.Equal(3, len(times)),
+			assert.Equal(0, round(times[0].Sub(start))),
+			assert.Equal(250*time.Millisecond, round(times[1].Sub(start))),
+			assert.Equal(300*time.Millisecond, round(times[2].Sub(start))))
+	})
+
+	t.Run("start paused", func(t *testing.T) {
+		timer := NewTimer(100 * time.Millisecond)
+		timer.(Pausable).Pause()
+		start := time.Now()
+		time.AfterFunc(150*time.Millisecond, timer.(Pausable).Resume)
+		time.AfterFunc(200*time.Millisecond, timer.Stop)
+		times := slices.Collect(timer.Ticks())
+		assert.That(t,
+			assert.Equal(1, len(times)),
+			assert.Equal(150*time.Millisecond, round(times[0].Sub(start))))
+	})
+}
+
+func TestWithJitter(t *testing.T) {
+	timer := NewTimer(100*time.Millisecond, WithJitter(50*time.Millisecond))
+	start := time.Now()
+	time.AfterFunc(480*time.Millisecond, timer.Stop)
+	times := slices.Collect(timer.Ticks())
+	assert.That(t, assert.Equal(5, len(times)))
+	for i, tick := range times {
+		offset := tick.Sub(start.Add(time.Duration(i) * 100 * time.Millisecond))
+		assert.That(t,
+			assert.True(offset >= 0),
+			assert.True(offset < 60*time.Millisecond))
+	}
+}
+
+func TestWithAlignment(t *testing.T) {
+	timer := NewTimer(100*time.Millisecond, WithAlignment(20*time.Millisecond))
+	time.AfterFunc(350*time.Millisecond, timer.Stop)
+	times := slices.Collect(timer.Ticks())
+	assert.That(t, assert.True(len(times) >= 3))
+	for _, tick := range times {
+		phase := tick.Sub(tick.Truncate(100 * time.Millisecond))
+		assert.That(t, assert.Equal(20*time.Millisecond, phase.Round(10*time.Millisecond)))
+	}
+}
+
+func TestTicker_NextTick(t *testing.T) {
+	timer := NewTimer(time.Hour)
+	assert.That(t, assert.True(timer.(Scheduled).NextTick().IsZero()))
+	go func() {
+		for range timer.Ticks() {
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.That(t, assert.Equal(time.Hour, time.Until(timer.(Scheduled).NextTick()).Round(time.Second)))
+
+	timer.(Pausable).Pause()
+	time.Sleep(10 * time.Millisecond)
+	assert.That(t,
+		assert.True(timer.(Pausable).Paused()),
+		assert.True(timer.(Scheduled).NextTick().IsZero()))
+	timer.(Pausable).Resume()
+	time.Sleep(10 * time.Millisecond)
+	assert.That(t, assert.Equal(time.Hour, time.Until(timer.(Scheduled).NextTick()).Round(time.Second)))
+
+	timer.Stop()
+	assert.That(t, assert.True(timer.(Scheduled).NextTick().IsZero()))
+}
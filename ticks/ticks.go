@@ -0,0 +1,137 @@
+// Package ticks provides the combinators, transforming the tick sequences,
+// returned by [ticker.Tickable.Ticks]. The results can be passed to
+// [loop.OnTick], or wrapped back into a [ticker.Tickable] with [Wrap] or
+// [Transform].
+package ticks
+
+import (
+	"iter"
+	"time"
+)
+
+// Indexed is a tick with its 0-based index in the sequence.
+type Indexed[T any] struct {
+	Index int
+	Tick  T
+}
+
+// Pair is a tick zipped with a value.
+type Pair[T, U any] struct {
+	Tick  T
+	Value U
+}
+
+// Map returns the sequence of the ticks converted by f.
+func Map[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for tick := range seq {
+			if !yield(f(tick)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns the sequence of the ticks, that satisfy the predicate.
+func Filter[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for tick := range seq {
+			if pred(tick) && !yield(tick) {
+				return
+			}
+		}
+	}
+}
+
+// Take returns the sequence of the first n ticks.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for tick := range seq {
+			if !yield(tick) {
+				return
+			}
+			if i++; i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns the sequence of the ticks after the first n.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for tick := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(tick) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns the sequence of the ticks until the first one, that does
+// not satisfy the predicate.
+func TakeWhile[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for tick := range seq {
+			if !pred(tick) || !yield(tick) {
+				return
+			}
+		}
+	}
+}
+
+// Every returns the sequence of every nth tick, starting from the first one.
+func Every[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	n = max(n, 1)
+	return func(yield func(T) bool) {
+		i := 0
+		for tick := range seq {
+			if i%n == 0 && !yield(tick) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Until returns the sequence of the ticks, that arrive before the deadline.
+// The sequence ends on the first tick after the deadline.
+func Until[T any](seq iter.Seq[T], deadline time.Time) iter.Seq[T] {
+	return TakeWhile(seq, func(T) bool {
+		return time.Now().Before(deadline)
+	})
+}
+
+// Enumerate returns the sequence of the ticks with their indexes.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq[Indexed[T]] {
+	return func(yield func(Indexed[T]) bool) {
+		i := 0
+		for tick := range seq {
+			if !yield(Indexed[T]{i, tick}) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Zip returns the sequence of the ticks paired with the values returned by f
+// on every tick.
+//
+// Example:
+//
+//	ticks.Zip(t.Ticks(), time.Now) // ticks with their arrival time
+func Zip[T, U any](seq iter.Seq[T], f func() U) iter.Seq[Pair[T, U]] {
+	return Map(seq, func(tick T) Pair[T, U] {
+		return Pair[T, U]{tick, f()}
+	})
+}
@@ -0,0 +1,52 @@
+package ticks
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestCombinators(t *testing.T) {
+	seq := slices.Values([]int{0, 1, 2, 3, 4, 5, 6})
+	even := func(i int) bool { return i%2 == 0 }
+	less := func(n int) func(int) bool {
+		return func(i int) bool { return i < n }
+	}
+
+	assert.That(t,
+		assert.EqualSlices([]string{"0", "1", "2", "3", "4", "5", "6"},
+			slices.Collect(Map(seq, strconv.Itoa))),
+		assert.EqualSlices([]int{0, 2, 4, 6}, slices.Collect(Filter(seq, even))),
+		assert.EqualSlices([]int{0, 1, 2}, slices.Collect(Take(seq, 3))),
+		assert.EqualSlices([]int{}, slices.Collect(Take(seq, 0))),
+		assert.EqualSlices([]int{4, 5, 6}, slices.Collect(Skip(seq, 4))),
+		assert.EqualSlices([]int{0, 1}, slices.Collect(TakeWhile(seq, less(2)))),
+		assert.EqualSlices([]int{0, 3, 6}, slices.Collect(Every(seq, 3))),
+		assert.EqualSlices([]int{0, 1, 2, 3, 4, 5, 6}, slices.Collect(Every(seq, 0))),
+		assert.EqualSlices([]int{}, slices.Collect(Until(seq, time.Now()))),
+		assert.EqualSlices([]int{0, 1, 2, 3, 4, 5, 6}, slices.Collect(Until(seq, time.Now().Add(time.Hour)))),
+		assert.EqualSlices([]Indexed[int]{{0, 2}, {1, 3}},
+			slices.Collect(Enumerate(Take(Skip(seq, 2), 2)))),
+	)
+
+	n := 0
+	counter := func() int { n++; return n }
+	assert.That(t,
+		assert.EqualSlices([]Pair[int, int]{{4, 1}, {6, 2}},
+			slices.Collect(Zip(Filter(Skip(seq, 3), even), counter))))
+}
+
+func TestCombinators_break(t *testing.T) {
+	seq := slices.Values([]int{0, 1, 2, 3, 4, 5, 6})
+	var got []int
+	for tick := range Every(Skip(Filter(seq, func(int) bool { return true }), 1), 2) {
+		if tick > 3 {
+			break
+		}
+		got = append(got, tick)
+	}
+	assert.That(t, assert.EqualSlices([]int{1, 3}, got))
+}
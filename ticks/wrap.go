@@ -0,0 +1,115 @@
+package ticks
+
+import (
+	"iter"
+
+	"github.com/parametalol/goticks/ticker"
+)
+
+type wrapped[T any] struct {
+	ticker.Tickable[T]
+	f func(iter.Seq[T]) iter.Seq[T]
+}
+
+// stoppableWrapped is a wrapped [ticker.Stoppable] source.
+type stoppableWrapped[T any] struct {
+	*wrapped[T]
+}
+
+// restartableWrapped is a wrapped [ticker.Restartable] source.
+type restartableWrapped[T any] struct {
+	stoppableWrapped[T]
+}
+
+var _ ticker.Tickable[any] = (*wrapped[any])(nil)
+var _ ticker.Waitable = (*wrapped[any])(nil)
+var _ ticker.Stoppable = stoppableWrapped[any]{}
+var _ ticker.Restartable = restartableWrapped[any]{}
+
+// Wrap returns a ticker, which [ticker.Tickable.Ticks] are the source ticks
+// transformed by f. The ticks sent with [ticker.Tickable.Tick] go to the
+// source, and [ticker.Waitable.Wait] is forwarded to the source if it
+// implements it. The returned ticker implements [ticker.Stoppable] and
+// [ticker.Restartable] only if the source does. See [Transform] for the
+// transformations, that change the tick type.
+//
+// Example:
+//
+//	// Run the task on every third tick.
+//	goticks.NewTask(ticks.Wrap(ticker.NewTimer(time.Second),
+//		func(seq iter.Seq[time.Time]) iter.Seq[time.Time] {
+//			return ticks.Every(seq, 3)
+//		}), task)
+func Wrap[T any](src ticker.Tickable[T], f func(iter.Seq[T]) iter.Seq[T]) ticker.Tickable[T] {
+	w := &wrapped[T]{src, f}
+	if _, ok := src.(ticker.Restartable); ok {
+		return restartableWrapped[T]{stoppableWrapped[T]{w}}
+	}
+	if _, ok := src.(ticker.Stoppable); ok {
+		return stoppableWrapped[T]{w}
+	}
+	return w
+}
+
+func (w *wrapped[T]) Ticks() iter.Seq[T] {
+	return w.f(w.Tickable.Ticks())
+}
+
+func (w *wrapped[T]) Wait() {
+	if s, ok := w.Tickable.(ticker.Waitable); ok {
+		s.Wait()
+	}
+}
+
+func (w stoppableWrapped[T]) Stop() {
+	w.Tickable.(ticker.Stoppable).Stop()
+}
+
+func (w restartableWrapped[T]) Start() {
+	w.Tickable.(ticker.Startable).Start()
+}
+
+type transformed[T, U any] struct {
+	ticker.Ticker[U]
+	src ticker.Tickable[T]
+}
+
+var _ ticker.Ticker[any] = (*transformed[any, any])(nil)
+var _ ticker.Restartable = (*transformed[any, any])(nil)
+
+// Transform returns a ticker, that dispatches the source ticks transformed by
+// f, which may change the tick type. The source is subscribed to on the first
+// call to [ticker.Tickable.Ticks] or on Start, and again after a restart, which
+// starts the tickers of the [ticker] package. Stop is forwarded to the source
+// if it implements [ticker.Stoppable]. The ticks sent with
+// [ticker.Tickable.Tick] are dispatched to the consumers directly. The ticker
+// is stopped when the transformed sequence ends.
+//
+// Example:
+//
+//	// Number the ticks.
+//	goticks.NewTask(ticks.Transform(ticker.NewTimer(time.Second),
+//		ticks.Enumerate[time.Time]), task)
+func Transform[T, U any](src ticker.Tickable[T], f func(iter.Seq[T]) iter.Seq[U]) ticker.Ticker[U] {
+	return &transformed[T, U]{
+		Ticker: ticker.FromSeq(func(yield func(U) bool) {
+			for tick := range f(src.Ticks()) {
+				if !yield(tick) {
+					return
+				}
+			}
+		}),
+		src: src,
+	}
+}
+
+func (t *transformed[T, U]) Start() {
+	t.Ticker.(ticker.Startable).Start()
+}
+
+func (t *transformed[T, U]) Stop() {
+	t.Ticker.Stop()
+	if s, ok := t.src.(ticker.Stoppable); ok {
+		s.Stop()
+	}
+}
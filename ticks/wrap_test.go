@@ -0,0 +1,92 @@
+package ticks
+
+import (
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/loop"
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+func TestWrap(t *testing.T) {
+	src := ticker.New[int]()
+	wrapped := Wrap(src, func(seq iter.Seq[int]) iter.Seq[int] {
+		return Take(Filter(seq, func(i int) bool { return i%2 == 1 }), 2)
+	})
+
+	var got []int
+	done := make(chan error)
+	ticks := wrapped.Ticks()
+	go func() {
+		done <- loop.OnTick(ticks, utils.Adapt[int](func(tick int) {
+			got = append(got, tick)
+		}))
+	}()
+	for i := range 4 {
+		wrapped.Tick(i).Wait()
+	}
+	assert.That(t,
+		assert.NoError(<-done),
+		assert.EqualSlices([]int{1, 3}, got))
+
+	wrapped.(ticker.Stoppable).Stop()
+	wrapped.(ticker.Waitable).Wait()
+}
+
+func skipFirst[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return Skip(seq, 1)
+}
+
+func TestWrap_interfaces(t *testing.T) {
+	_, stoppable := Wrap(ticker.New[int](), skipFirst[int]).(ticker.Stoppable)
+	_, restartable := Wrap(ticker.New[int](), skipFirst[int]).(ticker.Restartable)
+	_, timerRestartable := Wrap(ticker.NewTimer(time.Hour), skipFirst[time.Time]).(ticker.Restartable)
+	assert.That(t,
+		assert.True(stoppable),
+		assert.False(restartable),
+		assert.True(timerRestartable))
+}
+
+func TestTransform(t *testing.T) {
+	src := ticker.New[string]()
+	numbered := Transform(src, Enumerate[string])
+
+	var got []Indexed[string]
+	done := make(chan error)
+	ticks := numbered.Ticks()
+	go func() {
+		done <- loop.OnTick(ticks, utils.Adapt[Indexed[string]](func(tick Indexed[string]) {
+			got = append(got, tick)
+		}))
+	}()
+	// Wait for the source subscription.
+	for src.(ticker.Observable).Consumers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	src.Tick("a").Wait()
+	src.Tick("b").Wait()
+	numbered.Stop()
+	assert.That(t,
+		assert.NoError(<-done),
+		assert.EqualSlices([]Indexed[string]{{0, "a"}, {1, "b"}}, got))
+}
+
+func TestTransform_restart(t *testing.T) {
+	src := ticker.New[int]()
+	numbered := Transform(src, Enumerate[int])
+	restartable := numbered.(ticker.Restartable)
+	restartable.Start()
+	time.Sleep(10 * time.Millisecond)
+	// Stopping the source ends the first iteration, which must not stop the
+	// restarted ticker.
+	numbered.Stop()
+	restartable.Start()
+	time.Sleep(10 * time.Millisecond)
+	ticks := ticker.ToChan[Indexed[int]](numbered, 0)
+	src.Tick(7)
+	assert.That(t, assert.Equal(Indexed[int]{0, 7}, <-ticks))
+	numbered.Stop()
+}
@@ -0,0 +1,125 @@
+package goticks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/parametalol/goticks/utils"
+)
+
+// Tracer starts the spans for the task executions.
+// It is a minimal subset of a tracing API, such as OpenTelemetry, which can be
+// implemented by a thin adapter.
+type Tracer interface {
+	// Start creates a span, which is a child of the span in ctx, if any. The
+	// links are the contexts of the related spans, e.g. of the tick producer.
+	// The returned context must carry the new span.
+	Start(ctx context.Context, name string, links ...context.Context) (context.Context, Span)
+}
+
+// Span is a single traced operation.
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
+
+// TraceParent is implemented by the ticks, that carry the context of their
+// producer, so that the task span could be linked to it.
+type TraceParent interface {
+	TraceContext() context.Context
+}
+
+// TracedTick is a tick value, that carries the context of its producer.
+//
+// Example:
+//
+//	t := ticker.New[goticks.TracedTick[Event]]()
+//	t.Tick(goticks.TracedTick[Event]{Ctx: ctx, Value: event})
+type TracedTick[T any] struct {
+	Ctx   context.Context
+	Value T
+}
+
+var _ TraceParent = TracedTick[any]{}
+
+// TraceContext returns the producer context.
+func (t TracedTick[T]) TraceContext() context.Context {
+	return t.Ctx
+}
+
+// tickValue returns the value, recorded as the [AttributeTick] span attribute.
+func (t TracedTick[T]) tickValue() any {
+	return t.Value
+}
+
+// Span attribute keys, set by [Trace].
+const (
+	AttributeTick    = "goticks.tick"
+	AttributeAttempt = "goticks.attempt"
+	AttributeOutcome = "goticks.outcome"
+)
+
+// Values of the [AttributeOutcome] span attribute.
+const (
+	OutcomeOK        = "ok"
+	OutcomeError     = "error"
+	OutcomeStopped   = "stopped"
+	OutcomeCancelled = "cancelled"
+)
+
+// Trace starts a span for every task invocation, annotated with the tick
+// value, the attempt number and the outcome.
+// Wrapped by [utils.Retry], it starts a span per attempt. Wrap the retried task
+// once more to have the attempt spans as children of a single task span:
+//
+//	Trace[int](tracer, "name", utils.Retry[int](policy, Trace[int](tracer, "name", task)))
+func Trace[TickType any, Fn utils.Func[TickType]](tracer Tracer, name string, task Fn) func(context.Context, TickType) error {
+	adaptedTask := utils.Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		var links []context.Context
+		if parent, ok := any(tick).(TraceParent); ok && parent.TraceContext() != nil {
+			links = append(links, parent.TraceContext())
+		}
+		spanName := name
+		attempt, isAttempt := ctx.Value(utils.AttemptNumber).(int)
+		if isAttempt {
+			spanName = name + " attempt"
+		}
+		ctx, span := tracer.Start(ctx, spanName, links...)
+		defer span.End()
+		span.SetAttribute(AttributeTick, tickAttribute(tick))
+		if isAttempt {
+			span.SetAttribute(AttributeAttempt, attempt)
+		}
+		err := adaptedTask(ctx, tick)
+		switch {
+		case err == nil:
+			span.SetAttribute(AttributeOutcome, OutcomeOK)
+		case ctx.Err() != nil:
+			span.SetAttribute(AttributeOutcome, OutcomeCancelled)
+			span.RecordError(err)
+		case errors.Is(err, utils.ErrStopped):
+			span.SetAttribute(AttributeOutcome, OutcomeStopped)
+			span.RecordError(err)
+		default:
+			span.SetAttribute(AttributeOutcome, OutcomeError)
+			span.RecordError(err)
+		}
+		return err
+	}
+}
+
+// tickAttribute returns the value of the [AttributeTick] span attribute: the
+// formatted time for the [time.Time] ticks, and the value without the context
+// for the [TracedTick] ticks.
+func tickAttribute(tick any) any {
+	switch t := tick.(type) {
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	case interface{ tickValue() any }:
+		return tickAttribute(t.tickValue())
+	}
+	return tick
+}
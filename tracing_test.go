@@ -0,0 +1,97 @@
+package goticks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+type spanCtxKey struct{}
+
+type testSpan struct {
+	tracer *testTracer
+	name   string
+	parent string
+	links  int
+	attrs  map[string]any
+	err    error
+}
+
+func (s *testSpan) SetAttribute(key string, value any) { s.attrs[key] = value }
+func (s *testSpan) RecordError(err error)              { s.err = err }
+func (s *testSpan) End() {
+	s.tracer.mux.Lock()
+	defer s.tracer.mux.Unlock()
+	s.tracer.ended = append(s.tracer.ended, s)
+}
+
+type testTracer struct {
+	mux   sync.Mutex
+	ended []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, links ...context.Context) (context.Context, Span) {
+	span := &testSpan{tracer: t, name: name, links: len(links), attrs: map[string]any{}}
+	if parent, ok := ctx.Value(spanCtxKey{}).(*testSpan); ok {
+		span.parent = parent.name
+	}
+	return context.WithValue(ctx, spanCtxKey{}, span), span
+}
+
+func (t *testTracer) String() string {
+	var s string
+	for _, span := range t.ended {
+		s += fmt.Sprintf("%s<%s>%v;", span.name, span.parent, span.attrs)
+	}
+	return s
+}
+
+func TestTrace(t *testing.T) {
+	t.Run("retries", func(t *testing.T) {
+		tracer := &testTracer{}
+		errTest := errors.New("test")
+		attempts := 0
+		err := Trace[int](tracer, "task",
+			utils.Retry[int](utils.SimpleRetryPolicy(3),
+				Trace[int](tracer, "task", func() error {
+					attempts++
+					if attempts == 1 {
+						return errTest
+					}
+					return utils.ErrStopped
+				})))(context.Background(), 42)
+
+		assert.That(t,
+			assert.ErrorIs(err, utils.ErrStopped),
+			assert.Equal(
+				"task attempt<task>map[goticks.attempt:0 goticks.outcome:error goticks.tick:42];"+
+					"task attempt<task>map[goticks.attempt:1 goticks.outcome:stopped goticks.tick:42];"+
+					"task<>map[goticks.outcome:stopped goticks.tick:42];",
+				tracer.String()),
+			assert.ErrorIs(tracer.ended[0].err, errTest))
+	})
+
+	t.Run("linked tick", func(t *testing.T) {
+		tracer := &testTracer{}
+		tt := ticker.New[TracedTick[int]]()
+		NewTask(tt, Trace[TracedTick[int]](tracer, "task", func() {})).Start()
+
+		producerCtx, _ := tracer.Start(context.Background(), "producer")
+		tt.Tick(TracedTick[int]{Ctx: producerCtx, Value: 1}).Wait()
+		tt.Tick(TracedTick[int]{Value: 2}).Wait()
+
+		assert.That(t,
+			assert.Equal(2, len(tracer.ended)),
+			assert.Equal(1, tracer.ended[0].links),
+			assert.Equal(0, tracer.ended[1].links),
+			assert.Equal("", tracer.ended[0].parent),
+			assert.Equal[any](1, tracer.ended[0].attrs[AttributeTick]),
+			assert.Equal[any](2, tracer.ended[1].attrs[AttributeTick]))
+	})
+}
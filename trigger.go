@@ -0,0 +1,40 @@
+package goticks
+
+import (
+	"context"
+	"time"
+
+	"github.com/parametalol/goticks/ticker"
+)
+
+// Triggerable is implemented by the tasks, that can be run out of band.
+type Triggerable interface {
+	// Trigger runs the task immediately, with the current time as the tick
+	// for the [time.Time] tasks, or with the zero tick value otherwise.
+	Trigger(context.Context) error
+}
+
+var _ Triggerable = (*taskImpl[any])(nil)
+
+// Trigger runs the task immediately, with the current time as the tick for the
+// [time.Time] tasks, or with the zero tick value otherwise.
+// See TriggerWith.
+func (t *taskImpl[TickType]) Trigger(ctx context.Context) error {
+	tick, _ := any(time.Now()).(TickType)
+	return t.TriggerWith(ctx, tick)
+}
+
+// TriggerWith runs the task immediately with the given tick, even if the task
+// is stopped. The run goes through the same wrappers and options, as the runs
+// on the ticker ticks. The other consumers of the ticker are not affected.
+func (t *taskImpl[TickType]) TriggerWith(ctx context.Context, tick TickType) error {
+	err := t.run(ctx, tick)
+	if t.options.triggerReset && t.started.Load() {
+		if tt, ok := t.ticker.(ticker.TimeTicker); ok {
+			if p, ok := t.ticker.(ticker.Periodic); ok && p.Period() > 0 {
+				tt.Reset(p.Period())
+			}
+		}
+	}
+	return err
+}
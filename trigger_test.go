@@ -0,0 +1,103 @@
+package goticks
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/ticker"
+	"github.com/parametalol/goticks/utils"
+)
+
+func TestTask_Trigger(t *testing.T) {
+	t.Run("out of band", func(t *testing.T) {
+		ticker := ticker.New[int]()
+		var ticks, after []int
+		task := NewTask(ticker, func(tick int) {
+			ticks = append(ticks, tick)
+		}, WithAfterRun(func(_ context.Context, tick int, _ error, _ time.Duration) {
+			after = append(after, tick)
+		}))
+
+		other := ticker.Ticks()
+		var otherTicks []int
+		done := make(chan struct{})
+		go func() {
+			otherTicks = slices.Collect(other)
+			close(done)
+		}()
+
+		assert.That(t, assert.NoError(task.Trigger(context.Background())))
+		task.Start()
+		ticker.Tick(1).Wait()
+		assert.That(t, assert.NoError(task.TriggerWith(context.Background(), 2)))
+		task.Stop()
+		ticker.Stop()
+		<-done
+
+		assert.That(t,
+			assert.EqualSlices([]int{0, 1, 2}, ticks),
+			assert.EqualSlices([]int{0, 1, 2}, after),
+			assert.EqualSlices([]int{1}, otherTicks))
+	})
+
+	t.Run("overlap", func(t *testing.T) {
+		ticker := ticker.New[int]()
+		testCh := make(chan bool)
+		var ticks []int
+		task := NewTask(ticker, utils.NoOverlap[int](func(tick int) {
+			ticks = append(ticks, tick)
+			testCh <- true
+			testCh <- true
+		}))
+		task.Start()
+		wg := ticker.Tick(1)
+		<-testCh
+		assert.That(t, assert.NoError(task.TriggerWith(context.Background(), 2)))
+		<-testCh
+		wg.Wait()
+		assert.That(t, assert.EqualSlices([]int{1}, ticks))
+	})
+
+	t.Run("reset phase", func(t *testing.T) {
+		timer := ticker.NewTimer(100 * time.Millisecond)
+		var mux sync.Mutex
+		var ticks []time.Time
+		task := NewTask(timer, func(tick time.Time) {
+			mux.Lock()
+			defer mux.Unlock()
+			ticks = append(ticks, tick)
+		}, WithTriggerReset(), WithTickerStop())
+		start := time.Now()
+		task.Start()
+		time.Sleep(50 * time.Millisecond)
+		_ = task.Trigger(context.Background())
+		time.Sleep(125 * time.Millisecond)
+		task.Stop()
+
+		mux.Lock()
+		defer mux.Unlock()
+		assert.That(t,
+			assert.Equal(3, len(ticks)),
+			assert.Equal(50*time.Millisecond, ticks[1].Sub(start).Round(25*time.Millisecond)),
+			assert.Equal(150*time.Millisecond, ticks[2].Sub(start).Round(25*time.Millisecond)))
+	})
+
+	t.Run("no reset of one-shot tickers", func(t *testing.T) {
+		after := ticker.NewAfter(10 * time.Millisecond)
+		var runs atomic.Int32
+		task := NewTask(after, func() {
+			runs.Add(1)
+		}, WithTriggerReset(), WithTickerStop())
+		task.Start()
+		time.Sleep(50 * time.Millisecond)
+		_ = task.Trigger(context.Background())
+		time.Sleep(50 * time.Millisecond)
+		task.Stop()
+		assert.That(t, assert.Equal(int32(2), runs.Load()))
+	})
+}
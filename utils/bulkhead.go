@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBulkheadFull is returned when the semaphore has no capacity for the
+// requested weight.
+var ErrBulkheadFull = errors.New("bulkhead full")
+
+// Semaphore is a weighted semaphore, that can be shared by multiple tasks.
+type Semaphore interface {
+	// Acquire acquires the weight, waiting in the FIFO order until it is
+	// available or the context is done. It fails with [ErrBulkheadFull] if the
+	// weight exceeds the semaphore size.
+	Acquire(ctx context.Context, weight int64) error
+	// TryAcquire acquires the weight without waiting, and reports whether it
+	// succeeded.
+	TryAcquire(weight int64) bool
+	// Release releases the acquired weight.
+	Release(weight int64)
+	// Waiting returns the number of the callers waiting in Acquire.
+	Waiting() int
+	// Active returns the currently acquired weight.
+	Active() int64
+}
+
+type semaphoreWaiter struct {
+	weight int64
+	ready  chan struct{}
+}
+
+type semaphoreImpl struct {
+	size    int64
+	mux     sync.Mutex
+	active  int64
+	waiters list.List
+}
+
+var _ Semaphore = (*semaphoreImpl)(nil)
+
+// NewSemaphore returns a weighted semaphore of the given size.
+func NewSemaphore(size int64) Semaphore {
+	return &semaphoreImpl{size: size}
+}
+
+func (s *semaphoreImpl) Acquire(ctx context.Context, weight int64) error {
+	s.mux.Lock()
+	if weight > s.size {
+		s.mux.Unlock()
+		return ErrBulkheadFull
+	}
+	if s.size-s.active >= weight && s.waiters.Len() == 0 {
+		s.active += weight
+		s.mux.Unlock()
+		return nil
+	}
+	w := &semaphoreWaiter{weight, make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mux.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	select {
+	case <-w.ready:
+		// Acquired concurrently with the cancellation.
+		s.active -= weight
+	default:
+		s.waiters.Remove(elem)
+	}
+	s.notify()
+	return ctx.Err()
+}
+
+func (s *semaphoreImpl) TryAcquire(weight int64) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.size-s.active >= weight && s.waiters.Len() == 0 {
+		s.active += weight
+		return true
+	}
+	return false
+}
+
+func (s *semaphoreImpl) Release(weight int64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.active -= weight
+	if s.active < 0 {
+		panic("semaphore released more than acquired")
+	}
+	s.notify()
+}
+
+// notify wakes up the waiters in order, while there is capacity for them.
+func (s *semaphoreImpl) notify() {
+	for elem := s.waiters.Front(); elem != nil; elem = s.waiters.Front() {
+		w := elem.Value.(*semaphoreWaiter)
+		if s.size-s.active < w.weight {
+			break
+		}
+		s.active += w.weight
+		s.waiters.Remove(elem)
+		close(w.ready)
+	}
+}
+
+func (s *semaphoreImpl) Waiting() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.waiters.Len()
+}
+
+func (s *semaphoreImpl) Active() int64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.active
+}
+
+// Bulkhead limits the concurrent executions of the tasks, sharing the
+// semaphore. Every execution acquires the weight, waiting for the capacity
+// until the context is done.
+//
+// Example:
+//
+//	db := utils.NewSemaphore(5)
+//	a := utils.Bulkhead[time.Time](db, 1, cleanup)
+//	b := utils.Bulkhead[time.Time](db, 2, report)
+func Bulkhead[TickType any, Fn Func[TickType]](sem Semaphore, weight int64, task Fn) func(context.Context, TickType) error {
+	adaptedTask := Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		if err := sem.Acquire(ctx, weight); err != nil {
+			return err
+		}
+		defer sem.Release(weight)
+		return adaptedTask(ctx, tick)
+	}
+}
+
+// TryBulkhead is [Bulkhead], that fails with [ErrBulkheadFull] instead of
+// waiting for the capacity.
+func TryBulkhead[TickType any, Fn Func[TickType]](sem Semaphore, weight int64, task Fn) func(context.Context, TickType) error {
+	adaptedTask := Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		if !sem.TryAcquire(weight) {
+			return ErrBulkheadFull
+		}
+		defer sem.Release(weight)
+		return adaptedTask(ctx, tick)
+	}
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestSemaphore(t *testing.T) {
+	sem := NewSemaphore(3)
+	ctx := context.Background()
+
+	assert.That(t,
+		assert.NoError(sem.Acquire(ctx, 2)),
+		assert.True(sem.TryAcquire(1)),
+		assert.Not(assert.True(sem.TryAcquire(1))),
+		assert.ErrorIs(sem.Acquire(ctx, 4), ErrBulkheadFull),
+		assert.Equal(int64(3), sem.Active()))
+
+	acquired := make(chan struct{})
+	go func() {
+		_ = sem.Acquire(ctx, 2)
+		close(acquired)
+	}()
+	for sem.Waiting() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// The waiter keeps the FIFO order.
+	sem.Release(1)
+	assert.That(t, assert.Not(assert.True(sem.TryAcquire(1))))
+
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.That(t,
+		assert.ErrorIs(sem.Acquire(cancelled, 1), context.DeadlineExceeded),
+		assert.Equal(1, sem.Waiting()))
+
+	sem.Release(2)
+	<-acquired
+	assert.That(t,
+		assert.Equal(0, sem.Waiting()),
+		assert.Equal(int64(2), sem.Active()))
+}
+
+func TestBulkhead(t *testing.T) {
+	sem := NewSemaphore(2)
+	var running, maxRunning atomic.Int32
+	task := func() {
+		n := running.Add(1)
+		for m := maxRunning.Load(); n > m && !maxRunning.CompareAndSwap(m, n); m = maxRunning.Load() {
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+	}
+	a := Bulkhead[any](sem, 1, task)
+	b := Bulkhead[any](sem, 1, task)
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				_ = a(context.Background(), nil)
+			} else {
+				_ = b(context.Background(), nil)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.That(t,
+		assert.Equal(int32(2), maxRunning.Load()),
+		assert.Equal(int64(0), sem.Active()))
+}
+
+func TestTryBulkhead(t *testing.T) {
+	sem := NewSemaphore(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = Bulkhead[any](sem, 1, func() {
+			close(started)
+			<-release
+		})(context.Background(), nil)
+	}()
+	<-started
+	err := TryBulkhead[any](sem, 1, succeed)(context.Background(), nil)
+	close(release)
+	assert.That(t, assert.ErrorIs(err, ErrBulkheadFull))
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"errors"
+
+	"github.com/parametalol/curry"
+)
+
+// Predicate is a condition on the task context and tick.
+type Predicate[TickType any] interface {
+	curry.Func2R[context.Context, TickType, bool]
+}
+
+// Fallback runs the secondary task if the primary fails. If the errors are
+// provided, the secondary runs only if the primary error matches one of them
+// with [errors.Is].
+// The fallback is not run on [ErrStopped] or if the context is done. If both
+// tasks fail, the errors are joined.
+func Fallback[TickType any, Fn1 Func[TickType], Fn2 Func[TickType]](primary Fn1, secondary Fn2, errs ...error) func(context.Context, TickType) error {
+	adaptedPrimary := Adapt[TickType](primary)
+	adaptedSecondary := Adapt[TickType](secondary)
+	matches := func(err error) bool {
+		if errors.Is(err, ErrStopped) {
+			return false
+		}
+		if len(errs) == 0 {
+			return true
+		}
+		for _, target := range errs {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(ctx context.Context, tick TickType) error {
+		err := adaptedPrimary(ctx, tick)
+		if err == nil || ctx.Err() != nil || !matches(err) {
+			return err
+		}
+		if fallbackErr := adaptedSecondary(ctx, tick); fallbackErr != nil {
+			return errors.Join(err, fallbackErr)
+		}
+		return nil
+	}
+}
+
+// When runs the task only if the predicate holds.
+//
+// Example:
+//
+//	weekdays := func(tick time.Time) bool {
+//		return tick.Weekday() != time.Saturday && tick.Weekday() != time.Sunday
+//	}
+//	task := utils.When[time.Time](weekdays, report)
+func When[TickType any, P Predicate[TickType], Fn Func[TickType]](predicate P, task Fn) func(context.Context, TickType) error {
+	adaptedPredicate := curry.Adapt2R[context.Context, TickType, bool](predicate)
+	adaptedTask := Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		if !adaptedPredicate(ctx, tick) {
+			return nil
+		}
+		return adaptedTask(ctx, tick)
+	}
+}
+
+// Unless runs the task only if the predicate does not hold.
+func Unless[TickType any, P Predicate[TickType], Fn Func[TickType]](predicate P, task Fn) func(context.Context, TickType) error {
+	adaptedPredicate := curry.Adapt2R[context.Context, TickType, bool](predicate)
+	return When[TickType](func(ctx context.Context, tick TickType) bool {
+		return !adaptedPredicate(ctx, tick)
+	}, task)
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestFallback(t *testing.T) {
+	errOther := errors.New("other")
+	var calls int
+	secondary := func() { calls++ }
+
+	t.Run("primary ok", func(t *testing.T) {
+		calls = 0
+		err := Fallback[any](succeed, secondary)(context.Background(), nil)
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal(0, calls))
+	})
+	t.Run("primary failed", func(t *testing.T) {
+		calls = 0
+		err := Fallback[any](fail, secondary)(context.Background(), nil)
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal(1, calls))
+	})
+	t.Run("both failed", func(t *testing.T) {
+		err := Fallback[any](fail, func() error { return errOther })(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, errTest),
+			assert.ErrorIs(err, errOther))
+	})
+	t.Run("matching", func(t *testing.T) {
+		calls = 0
+		errMatched := Fallback[any](fail, secondary, errOther, errTest)(context.Background(), nil)
+		errNotMatched := Fallback[any](fail, secondary, errOther)(context.Background(), nil)
+		assert.That(t,
+			assert.NoError(errMatched),
+			assert.ErrorIs(errNotMatched, errTest),
+			assert.Equal(1, calls))
+	})
+	t.Run("stopped", func(t *testing.T) {
+		calls = 0
+		err := Fallback[any](stop, secondary)(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, ErrStopped),
+			assert.Equal(0, calls))
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		calls = 0
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := Fallback[any](func(ctx context.Context) error { return ctx.Err() }, secondary)(ctx, nil)
+		assert.That(t,
+			assert.ErrorIs(err, context.Canceled),
+			assert.Equal(0, calls))
+	})
+}
+
+func TestWhenUnless(t *testing.T) {
+	var ticks []int
+	task := func(tick int) { ticks = append(ticks, tick) }
+	even := func(tick int) bool { return tick%2 == 0 }
+
+	when := When[int](even, task)
+	unless := Unless[int](even, task)
+	for i := range 4 {
+		_ = when(context.Background(), i)
+		_ = unless(context.Background(), i)
+	}
+	assert.That(t, assert.EqualSlices([]int{0, 1, 2, 3}, ticks))
+
+	err := When[time.Time](func(context.Context) bool { return true }, func() error {
+		return errTest
+	})(context.Background(), time.Now())
+	assert.That(t, assert.ErrorIs(err, errTest))
+}
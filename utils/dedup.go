@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrDedupPanic is returned by [Dedup] to the callers, waiting for an
+// execution, that has panicked.
+var ErrDedupPanic = errors.New("deduplicated execution panicked")
+
+type dedupCall struct {
+	done chan struct{}
+	err  error
+}
+
+// Dedup collapses the concurrent executions of the task with the same tick
+// key into one execution, which result is shared by all the callers.
+// The shared execution runs with the context of the first caller. The other
+// callers stop waiting and return the context error if their context is done.
+// If the execution panics, the panic is propagated to the first caller, and
+// the others receive [ErrDedupPanic].
+func Dedup[TickType any, K comparable, Fn Func[TickType]](key func(TickType) K, task Fn) func(context.Context, TickType) error {
+	return DedupTTL(0, key, task)
+}
+
+// DedupTTL is [Dedup], that also shares the result of an execution with the
+// calls with the same key for the ttl after the execution has finished,
+// suppressing the duplicates within the time window.
+func DedupTTL[TickType any, K comparable, Fn Func[TickType]](ttl time.Duration, key func(TickType) K, task Fn) func(context.Context, TickType) error {
+	adaptedTask := Adapt[TickType](task)
+	var mux sync.Mutex
+	calls := make(map[K]*dedupCall)
+	return func(ctx context.Context, tick TickType) error {
+		k := key(tick)
+		mux.Lock()
+		if c, ok := calls[k]; ok {
+			mux.Unlock()
+			select {
+			case <-c.done:
+				return c.err
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		c := &dedupCall{done: make(chan struct{})}
+		calls[k] = c
+		mux.Unlock()
+
+		forget := func() {
+			mux.Lock()
+			defer mux.Unlock()
+			delete(calls, k)
+		}
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			// The task has panicked: release the waiters, and do not share
+			// the result.
+			r := recover()
+			c.err = fmt.Errorf("%w: %v", ErrDedupPanic, r)
+			close(c.done)
+			forget()
+			if r != nil {
+				panic(r)
+			}
+		}()
+		c.err = adaptedTask(ctx, tick)
+		completed = true
+		close(c.done)
+		if ttl > 0 {
+			time.AfterFunc(ttl, forget)
+		} else {
+			forget()
+		}
+		return c.err
+	}
+}
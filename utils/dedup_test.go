@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestDedup(t *testing.T) {
+	key := func(tick string) string { return tick }
+
+	t.Run("concurrent", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		task := Dedup(key, func(tick string) error {
+			calls.Add(1)
+			<-release
+			return errTest
+		})
+		var wg sync.WaitGroup
+		errs := make([]error, 3)
+		for i, tick := range []string{"a", "a", "b"} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = task(context.Background(), tick)
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.That(t,
+			assert.Equal(int32(2), calls.Load()),
+			assert.ErrorIs(errs[0], errTest),
+			assert.ErrorIs(errs[1], errTest),
+			assert.ErrorIs(errs[2], errTest))
+
+		// The finished executions are not shared.
+		_ = task(context.Background(), "a")
+		assert.That(t, assert.Equal(int32(3), calls.Load()))
+	})
+
+	t.Run("cancelled wait", func(t *testing.T) {
+		release := make(chan struct{})
+		task := Dedup(key, func(string) { <-release })
+		go func() { _ = task(context.Background(), "a") }()
+		time.Sleep(10 * time.Millisecond)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := task(ctx, "a")
+		close(release)
+		assert.That(t, assert.ErrorIs(err, context.Canceled))
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		var calls atomic.Int32
+		task := DedupTTL(50*time.Millisecond, key, func(string) { calls.Add(1) })
+		_ = task(context.Background(), "a")
+		_ = task(context.Background(), "a")
+		_ = task(context.Background(), "b")
+		assert.That(t, assert.Equal(int32(2), calls.Load()))
+		time.Sleep(100 * time.Millisecond)
+		_ = task(context.Background(), "a")
+		assert.That(t, assert.Equal(int32(3), calls.Load()))
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var calls atomic.Int32
+		running := make(chan struct{})
+		release := make(chan struct{})
+		task := DedupTTL(time.Hour, key, func(string) {
+			if calls.Add(1) == 1 {
+				close(running)
+				<-release
+				panic("oops")
+			}
+		})
+		recovered := make(chan any)
+		go func() {
+			defer func() { recovered <- recover() }()
+			_ = task(context.Background(), "a")
+		}()
+		<-running
+		waiter := make(chan error)
+		go func() { waiter <- task(context.Background(), "a") }()
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		assert.That(t,
+			assert.Equal[any]("oops", <-recovered),
+			assert.ErrorIs(<-waiter, ErrDedupPanic),
+			// The panicked execution is not shared.
+			assert.NoError(task(context.Background(), "a")),
+			assert.Equal(int32(2), calls.Load()))
+	})
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/parametalol/goticks/lease"
+)
+
+// Exclusive runs the task only if the lease is acquired from the locker,
+// skipping the tick otherwise. The lease is renewed every third of the ttl
+// while the task is running, and released when the task finishes. If the
+// renewal fails, the task context is cancelled with [lease.ErrLost] as the
+// cause. The lease is not renewed with a ttl too small to be divided, e.g. 0
+// for the lockers, that do not use the TTL, such as [lease.NewFile].
+func Exclusive[TickType any, Fn Func[TickType]](locker lease.Locker, ttl time.Duration, task Fn) func(context.Context, TickType) error {
+	adaptedTask := Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		l, err := locker.TryAcquire(ctx, ttl)
+		if errors.Is(err, lease.ErrNotAcquired) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		defer func() { _ = l.Release() }()
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		if ttl/3 <= 0 {
+			return adaptedTask(ctx, tick)
+		}
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			renew := time.NewTicker(ttl / 3)
+			defer renew.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-renew.C:
+					if err := l.Renew(ctx); err != nil {
+						cancel(lease.ErrLost)
+						return
+					}
+				}
+			}
+		}()
+		return adaptedTask(ctx, tick)
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/internal/leasetest"
+	"github.com/parametalol/goticks/lease"
+)
+
+func TestExclusive(t *testing.T) {
+	t.Run("one at a time", func(t *testing.T) {
+		locker := lease.NewMemory()
+		var runs atomic.Int32
+		testCh := make(chan bool)
+		task := Exclusive[any](locker, time.Minute, func() {
+			runs.Add(1)
+			testCh <- true
+			testCh <- true
+		})
+		done := make(chan struct{})
+		go func() {
+			_ = task(context.Background(), 0)
+			close(done)
+		}()
+		<-testCh
+		assert.That(t, assert.NoError(task(context.Background(), 0)))
+		<-testCh
+		<-done
+		assert.That(t, assert.Equal(int32(1), runs.Load()))
+
+		assert.That(t, assert.NoError(
+			Exclusive[any](locker, time.Minute, func() { runs.Add(1) })(context.Background(), 0)))
+		assert.That(t, assert.Equal(int32(2), runs.Load()))
+	})
+
+	t.Run("renewal", func(t *testing.T) {
+		locker := lease.NewMemory()
+		err := Exclusive[any](locker, 30*time.Millisecond, func(ctx context.Context) error {
+			time.Sleep(100 * time.Millisecond)
+			return context.Cause(ctx)
+		})(context.Background(), 0)
+		assert.That(t, assert.NoError(err))
+	})
+
+	t.Run("zero ttl", func(t *testing.T) {
+		err := Exclusive[any](lease.NewMemory(), 0, func() {
+			time.Sleep(10 * time.Millisecond)
+		})(context.Background(), 0)
+		assert.That(t, assert.NoError(err))
+	})
+
+	t.Run("lost", func(t *testing.T) {
+		err := Exclusive[any](leasetest.Lost(), 30*time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return context.Cause(ctx)
+		})(context.Background(), 0)
+		assert.That(t, assert.ErrorIs(err, lease.ErrLost))
+	})
+}
@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultTickEnv is the environment variable, that passes the tick to the
+// processes run by [Exec].
+const DefaultTickEnv = "GOTICKS_TICK"
+
+// AttemptEnv is the environment variable, that passes the attempt number to
+// the processes run by [Exec], if set in the context by [Retry].
+const AttemptEnv = "GOTICKS_ATTEMPT"
+
+// ExitError is returned by the [Exec] tasks, when the process exits with a
+// non-zero code.
+type ExitError struct {
+	Code int
+	// Stopped is true for the stop codes, in which case the error matches
+	// [ErrStopped].
+	Stopped bool
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+func (e *ExitError) Unwrap() error {
+	if e.Stopped {
+		return ErrStopped
+	}
+	return nil
+}
+
+type execOptions struct {
+	stdout, stderr io.Writer
+	logger         *slog.Logger
+	dir            string
+	env            []string
+	tickEnv        string
+	tickStdin      bool
+	stopCodes      []int
+	waitDelay      time.Duration
+}
+
+type ExecOption func(*execOptions)
+
+// WithOutput sets the writers of the process stdout and stderr.
+// By default the output is discarded.
+func WithOutput(stdout, stderr io.Writer) ExecOption {
+	return func(o *execOptions) {
+		o.stdout = stdout
+		o.stderr = stderr
+	}
+}
+
+// WithOutputLogger logs every line of the process output with the logger:
+// stdout at the info level, and stderr at the warning level.
+func WithOutputLogger(logger *slog.Logger) ExecOption {
+	return func(o *execOptions) {
+		o.logger = logger
+	}
+}
+
+// WithDir sets the working directory of the process.
+func WithDir(dir string) ExecOption {
+	return func(o *execOptions) {
+		o.dir = dir
+	}
+}
+
+// WithEnv adds the "key=value" environment variables to the environment of
+// the current process.
+func WithEnv(env ...string) ExecOption {
+	return func(o *execOptions) {
+		o.env = append(o.env, env...)
+	}
+}
+
+// WithTickEnv sets the environment variable, that passes the tick, instead of
+// [DefaultTickEnv]. An empty name disables passing the tick via environment.
+func WithTickEnv(name string) ExecOption {
+	return func(o *execOptions) {
+		o.tickEnv = name
+	}
+}
+
+// WithTickStdin writes the tick to the process stdin.
+func WithTickStdin() ExecOption {
+	return func(o *execOptions) {
+		o.tickStdin = true
+	}
+}
+
+// WithStopCodes makes the [ExitError] of the given exit codes match
+// [ErrStopped], stopping the task loop.
+func WithStopCodes(codes ...int) ExecOption {
+	return func(o *execOptions) {
+		o.stopCodes = append(o.stopCodes, codes...)
+	}
+}
+
+// WithWaitDelay sets the time to wait for the process output to be closed
+// after the process group is killed. Defaults to one second.
+func WithWaitDelay(d time.Duration) ExecOption {
+	return func(o *execOptions) {
+		o.waitDelay = d
+	}
+}
+
+// Exec returns a task, that runs the process with the arguments on every
+// tick, and waits for it to finish. See [ExecWith].
+func Exec[TickType any](name string, args ...string) func(context.Context, TickType) error {
+	return ExecWith[TickType](name, args)
+}
+
+// Shell returns a task, that runs the command with /bin/sh on every tick.
+// See [ExecWith].
+func Shell[TickType any](command string, opts ...ExecOption) func(context.Context, TickType) error {
+	return ExecWith[TickType]("/bin/sh", []string{"-c", command}, opts...)
+}
+
+// ExecWith returns a task, that runs the process with the arguments on every
+// tick, and waits for it to finish.
+//
+// The tick is passed to the process in the [DefaultTickEnv] environment
+// variable, and optionally via stdin: the strings and byte slices as is, the
+// [time.Time] ticks in the RFC 3339 format, and the other values as JSON.
+//
+// On the context cancellation, the process group is killed on unix, and the
+// context error is returned. A non-zero exit code is returned as [ExitError].
+//
+// Example:
+//
+//	task := utils.Timeout[time.Time](time.Minute, utils.ExecWith[time.Time](
+//		"/usr/local/bin/cleanup.sh", []string{"--verbose"},
+//		utils.WithOutputLogger(logger),
+//		utils.WithStopCodes(3)))
+func ExecWith[TickType any](name string, args []string, opts ...ExecOption) func(context.Context, TickType) error {
+	o := execOptions{tickEnv: DefaultTickEnv, waitDelay: time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return func(ctx context.Context, tick TickType) error {
+		data, err := formatTick(tick)
+		if err != nil {
+			return err
+		}
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Dir = o.dir
+		cmd.WaitDelay = o.waitDelay
+		setProcessGroup(cmd)
+		cmd.Env = append(os.Environ(), o.env...)
+		if o.tickEnv != "" {
+			cmd.Env = append(cmd.Env, o.tickEnv+"="+string(data))
+		}
+		if attempt, ok := ctx.Value(AttemptNumber).(int); ok {
+			cmd.Env = append(cmd.Env, AttemptEnv+"="+strconv.Itoa(attempt))
+		}
+		if o.tickStdin {
+			cmd.Stdin = bytes.NewReader(data)
+		}
+		cmd.Stdout, cmd.Stderr = o.stdout, o.stderr
+		if o.logger != nil {
+			stdout := &lineLogger{logger: o.logger, level: slog.LevelInfo, stream: "stdout"}
+			stderr := &lineLogger{logger: o.logger, level: slog.LevelWarn, stream: "stderr"}
+			defer stdout.flush()
+			defer stderr.flush()
+			cmd.Stdout = writers(cmd.Stdout, stdout)
+			cmd.Stderr = writers(cmd.Stderr, stderr)
+		}
+
+		err = cmd.Run()
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+			return nil
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case errors.As(err, &exitErr):
+			code := exitErr.ExitCode()
+			return &ExitError{Code: code, Stopped: slices.Contains(o.stopCodes, code)}
+		}
+		return err
+	}
+}
+
+func writers(w, logger io.Writer) io.Writer {
+	if w == nil {
+		return logger
+	}
+	return io.MultiWriter(w, logger)
+}
+
+func formatTick(tick any) ([]byte, error) {
+	switch t := tick.(type) {
+	case string:
+		return []byte(t), nil
+	case []byte:
+		return t, nil
+	case time.Time:
+		return []byte(t.Format(time.RFC3339Nano)), nil
+	}
+	return json.Marshal(tick)
+}
+
+// lineLogger logs the written data line by line.
+type lineLogger struct {
+	logger *slog.Logger
+	level  slog.Level
+	stream string
+
+	mux sync.Mutex
+	buf []byte
+}
+
+func (l *lineLogger) Write(p []byte) (int, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		l.log(string(l.buf[:i]))
+		l.buf = l.buf[i+1:]
+	}
+}
+
+func (l *lineLogger) log(line string) {
+	l.logger.Log(context.Background(), l.level, line, "stream", l.stream)
+}
+
+// flush logs the last line without the line break.
+func (l *lineLogger) flush() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if len(l.buf) > 0 {
+		l.log(string(l.buf))
+		l.buf = nil
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package utils
+
+import "os/exec"
+
+// setProcessGroup keeps the default behavior of killing the process on the
+// context cancellation.
+func setProcessGroup(*exec.Cmd) {}
@@ -0,0 +1,91 @@
+//go:build unix
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+func TestExec(t *testing.T) {
+	t.Run("output", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		err := Shell[string]("echo out; echo err >&2", WithOutput(&stdout, &stderr))(context.Background(), "")
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal("out\n", stdout.String()),
+			assert.Equal("err\n", stderr.String()))
+	})
+
+	t.Run("logger", func(t *testing.T) {
+		var log bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&log, &slog.HandlerOptions{
+			ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		err := Shell[string]("echo a; printf b >&2", WithOutputLogger(logger))(context.Background(), "")
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal("level=INFO msg=a stream=stdout\nlevel=WARN msg=b stream=stderr\n", log.String()))
+	})
+
+	t.Run("exit codes", func(t *testing.T) {
+		task := Shell[string]("exit $CODE", WithStopCodes(3), WithTickEnv("CODE"))
+		err := task(context.Background(), "2")
+		exitErr, _ := err.(*ExitError)
+		assert.That(t,
+			assert.True(exitErr != nil),
+			assert.Equal("exit status 2", err.Error()),
+			assert.Not(assert.ErrorIs(err, ErrStopped)),
+			assert.ErrorIs(task(context.Background(), "3"), ErrStopped),
+			assert.NoError(task(context.Background(), "0")))
+	})
+
+	t.Run("tick", func(t *testing.T) {
+		var stdout bytes.Buffer
+		tick := time.Date(2025, time.June, 2, 10, 0, 0, 0, time.UTC)
+		err := Retry[time.Time](SimpleRetryPolicy(1), Shell[time.Time](
+			`echo "$GOTICKS_TICK $GOTICKS_ATTEMPT $A"; cat`,
+			WithOutput(&stdout, nil), WithTickStdin(), WithEnv("A=a")))(context.Background(), tick)
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal("2025-06-02T10:00:00Z 0 a\n2025-06-02T10:00:00Z", stdout.String()))
+
+		stdout.Reset()
+		err = ExecWith[map[string]int]("cat", nil, WithOutput(&stdout, nil), WithTickStdin())(
+			context.Background(), map[string]int{"a": 1})
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal(`{"a":1}`, stdout.String()))
+	})
+
+	t.Run("process group kill", func(t *testing.T) {
+		var stdout bytes.Buffer
+		start := time.Now()
+		// The background sleep keeps the output open, unless the whole group
+		// is killed.
+		err := Timeout[string](100*time.Millisecond, ExecWith[string](
+			"/bin/sh", []string{"-c", "sleep 5 & sleep 5"},
+			WithOutput(&stdout, nil), WithWaitDelay(3*time.Second)))(context.Background(), "")
+		assert.That(t,
+			assert.ErrorIs(err, context.DeadlineExceeded),
+			assert.True(time.Since(start) < time.Second))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := Exec[string]("/nonexistent/command")(context.Background(), "")
+		assert.That(t,
+			assert.Not(assert.NoError(err)),
+			assert.True(strings.Contains(err.Error(), "no such file")))
+	})
+}
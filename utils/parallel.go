@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNoQuorum is returned by [Quorum] when too many tasks fail.
+var ErrNoQuorum = errors.New("no quorum")
+
+// ErrNoTasks is returned by [Race] without tasks.
+var ErrNoTasks = errors.New("no tasks")
+
+// Parallel executes the tasks concurrently and waits for all of them.
+// It returns the joined errors of the failed tasks. If one of the tasks fails
+// with [ErrStopped], the context of the others is cancelled.
+func Parallel[TickType any](tasks ...func(context.Context, TickType) error) func(context.Context, TickType) error {
+	return func(ctx context.Context, tick TickType) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		errs := make([]error, len(tasks))
+		var wg sync.WaitGroup
+		for i, task := range tasks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = task(ctx, tick)
+				if errors.Is(errs[i], ErrStopped) {
+					cancel()
+				}
+			}()
+		}
+		wg.Wait()
+		return errors.Join(errs...)
+	}
+}
+
+// Race executes the tasks concurrently, and returns on the first success,
+// cancelling the context of the others. If all the tasks fail, it returns the
+// joined errors. Without tasks, it returns [ErrNoTasks].
+// If one of the tasks fails with [ErrStopped], the others are cancelled and
+// the error is returned.
+func Race[TickType any](tasks ...func(context.Context, TickType) error) func(context.Context, TickType) error {
+	return func(ctx context.Context, tick TickType) error {
+		if len(tasks) == 0 {
+			return ErrNoTasks
+		}
+		return gather(ctx, tick, 1, tasks)
+	}
+}
+
+// Quorum executes the tasks concurrently, and returns on k successes,
+// cancelling the context of the others. It fails with [ErrNoQuorum], joined
+// with the errors of the failed tasks, as soon as the quorum cannot be reached.
+// If one of the tasks fails with [ErrStopped], the others are cancelled and
+// the error is returned.
+func Quorum[TickType any](k int, tasks ...func(context.Context, TickType) error) func(context.Context, TickType) error {
+	return func(ctx context.Context, tick TickType) error {
+		if k > len(tasks) {
+			return ErrNoQuorum
+		}
+		err := gather(ctx, tick, k, tasks)
+		if err != nil && !errors.Is(err, ErrStopped) {
+			return errors.Join(ErrNoQuorum, err)
+		}
+		return err
+	}
+}
+
+// gather runs the tasks until k of them succeed, or the success is not
+// possible anymore. All the tasks are finished on return.
+func gather[TickType any](ctx context.Context, tick TickType, k int, tasks []func(context.Context, TickType) error) error {
+	if k <= 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	results := make(chan error, len(tasks))
+	for _, task := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- task(ctx, tick)
+		}()
+	}
+	var errs []error
+	successes := 0
+	for range tasks {
+		err := <-results
+		switch {
+		case err == nil:
+			if successes++; successes >= k {
+				return nil
+			}
+		case errors.Is(err, ErrStopped):
+			return err
+		default:
+			if errs = append(errs, err); len(errs) > len(tasks)-k {
+				return errors.Join(errs...)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Hedge executes the task, and if it does not finish within the delay, starts
+// a duplicate attempt. The first success wins, cancelling the context of the
+// other attempt. The attempt number is set in the context under the
+// [AttemptNumber] key.
+// If the first attempt fails before the delay, the error is returned without
+// hedging. If an attempt fails with [ErrStopped], the other is cancelled and
+// the error is returned.
+func Hedge[TickType any, Fn Func[TickType]](delay time.Duration, task Fn) func(context.Context, TickType) error {
+	adaptedTask := Adapt[TickType](task)
+	return func(ctx context.Context, tick TickType) error {
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+		results := make(chan error, 2)
+		attempt := func(i int) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results <- adaptedTask(context.WithValue(ctx, AttemptNumber, i), tick)
+			}()
+		}
+		attempt(0)
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case err := <-results:
+			return err
+		case <-timer.C:
+			attempt(1)
+		}
+		var errs []error
+		for range 2 {
+			err := <-results
+			if err == nil || errors.Is(err, ErrStopped) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+)
+
+var errTest = errors.New("test")
+
+func fail(context.Context, any) error { return errTest }
+
+func succeed(context.Context, any) error { return nil }
+
+func stop(context.Context, any) error { return ErrStopped }
+
+// block waits for the context cancellation, counting the cancelled calls.
+func block(cancelled *atomic.Int32) func(context.Context, any) error {
+	return func(ctx context.Context, _ any) error {
+		<-ctx.Done()
+		cancelled.Add(1)
+		return ctx.Err()
+	}
+}
+
+func TestParallel(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		var i atomic.Int32
+		inc := func(context.Context, any) error {
+			i.Add(1)
+			return nil
+		}
+		err := Parallel(inc, inc, fail, inc)(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, errTest),
+			assert.Equal(int32(3), i.Load()))
+	})
+	t.Run("stopped", func(t *testing.T) {
+		var cancelled atomic.Int32
+		err := Parallel(block(&cancelled), stop, block(&cancelled))(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, ErrStopped),
+			assert.ErrorIs(err, context.Canceled),
+			assert.Equal(int32(2), cancelled.Load()))
+	})
+}
+
+func TestRace(t *testing.T) {
+	t.Run("first success", func(t *testing.T) {
+		var cancelled atomic.Int32
+		err := Race(block(&cancelled), fail, succeed, block(&cancelled))(context.Background(), nil)
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal(int32(2), cancelled.Load()))
+	})
+	t.Run("all failed", func(t *testing.T) {
+		err := Race(fail, fail)(context.Background(), nil)
+		assert.That(t, assert.ErrorIs(err, errTest))
+	})
+	t.Run("no tasks", func(t *testing.T) {
+		err := Race[any]()(context.Background(), nil)
+		assert.That(t, assert.ErrorIs(err, ErrNoTasks))
+	})
+	t.Run("stopped", func(t *testing.T) {
+		var cancelled atomic.Int32
+		err := Race(block(&cancelled), stop)(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, ErrStopped),
+			assert.Equal(int32(1), cancelled.Load()))
+	})
+}
+
+func TestQuorum(t *testing.T) {
+	t.Run("reached", func(t *testing.T) {
+		var cancelled atomic.Int32
+		err := Quorum(2, succeed, fail, block(&cancelled), succeed)(context.Background(), nil)
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal(int32(1), cancelled.Load()))
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		var cancelled atomic.Int32
+		err := Quorum(2, fail, block(&cancelled), fail)(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, ErrNoQuorum),
+			assert.ErrorIs(err, errTest),
+			assert.Equal(int32(1), cancelled.Load()))
+	})
+	t.Run("too few tasks", func(t *testing.T) {
+		err := Quorum(3, succeed, succeed)(context.Background(), nil)
+		assert.That(t, assert.ErrorIs(err, ErrNoQuorum))
+	})
+	t.Run("stopped", func(t *testing.T) {
+		err := Quorum(1, stop, fail)(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, ErrStopped),
+			assert.Not(assert.ErrorIs(err, ErrNoQuorum)))
+	})
+}
+
+func TestHedge(t *testing.T) {
+	t.Run("fast", func(t *testing.T) {
+		var calls atomic.Int32
+		err := Hedge[any](time.Second, func() {
+			calls.Add(1)
+		})(context.Background(), nil)
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal(int32(1), calls.Load()))
+	})
+	t.Run("slow", func(t *testing.T) {
+		var cancelled atomic.Int32
+		err := Hedge[any](10*time.Millisecond, func(ctx context.Context) error {
+			if attempt, _ := getAttemptNumber(ctx); attempt == 0 {
+				return block(&cancelled)(ctx, nil)
+			}
+			return nil
+		})(context.Background(), nil)
+		assert.That(t,
+			assert.NoError(err),
+			assert.Equal(int32(1), cancelled.Load()))
+	})
+	t.Run("both failed", func(t *testing.T) {
+		err := Hedge[any](10*time.Millisecond, func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return errTest
+		})(context.Background(), nil)
+		assert.That(t, assert.ErrorIs(err, errTest))
+	})
+	t.Run("stopped", func(t *testing.T) {
+		var cancelled atomic.Int32
+		err := Hedge[any](10*time.Millisecond, func(ctx context.Context) error {
+			if attempt, _ := getAttemptNumber(ctx); attempt == 0 {
+				return block(&cancelled)(ctx, nil)
+			}
+			return ErrStopped
+		})(context.Background(), nil)
+		assert.That(t,
+			assert.ErrorIs(err, ErrStopped),
+			assert.Equal(int32(1), cancelled.Load()))
+	})
+}
@@ -0,0 +1,194 @@
+package goticks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/parametalol/goticks/utils"
+)
+
+// FailurePolicy defines how a [Workflow] handles the failed nodes.
+type FailurePolicy int
+
+const (
+	// SkipDependants skips the nodes, that depend directly or transitively on
+	// a failed node.
+	SkipDependants FailurePolicy = iota
+	// ContinueOnFailure runs the dependants of the failed nodes.
+	ContinueOnFailure
+)
+
+type nodeOptions struct {
+	deps    []string
+	timeout time.Duration
+	retry   utils.RetryPolicy
+}
+
+type NodeOption func(*nodeOptions)
+
+// DependsOn declares the nodes, that have to finish before the node starts.
+func DependsOn(names ...string) NodeOption {
+	return func(o *nodeOptions) {
+		o.deps = append(o.deps, names...)
+	}
+}
+
+// NodeTimeout wraps the node task with [utils.Timeout]. With [NodeRetry], the
+// timeout applies to every attempt.
+func NodeTimeout(timeout time.Duration) NodeOption {
+	return func(o *nodeOptions) {
+		o.timeout = timeout
+	}
+}
+
+// NodeRetry wraps the node task with [utils.Retry].
+func NodeRetry(policy utils.RetryPolicy) NodeOption {
+	return func(o *nodeOptions) {
+		o.retry = policy
+	}
+}
+
+type workflowNode[TickType any] struct {
+	name string
+	task func(context.Context, TickType) error
+	deps []string
+}
+
+// Workflow is a builder of a task, that runs a graph of dependent tasks.
+type Workflow[TickType any] struct {
+	nodes  []*workflowNode[TickType]
+	policy FailurePolicy
+}
+
+// NewWorkflow returns an empty workflow with the failure policy.
+//
+// Example:
+//
+//	wf := NewWorkflow[time.Time](SkipDependants)
+//	wf.Add("extract", extract)
+//	wf.Add("transform", transform, DependsOn("extract"))
+//	wf.Add("load", load, DependsOn("transform"), NodeTimeout(time.Minute))
+//	etl, err := wf.Build()
+//	...
+//	NewTask(ticker.NewTimer(24*time.Hour), etl).Start()
+func NewWorkflow[TickType any](policy FailurePolicy) *Workflow[TickType] {
+	return &Workflow[TickType]{policy: policy}
+}
+
+// Add adds a named node to the workflow.
+func (w *Workflow[TickType]) Add(name string, task func(context.Context, TickType) error, opts ...NodeOption) *Workflow[TickType] {
+	var o nodeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.timeout > 0 {
+		task = utils.Timeout[TickType](o.timeout, task)
+	}
+	if o.retry != nil {
+		task = utils.Retry[TickType](o.retry, task)
+	}
+	w.nodes = append(w.nodes, &workflowNode[TickType]{name, task, o.deps})
+	return w
+}
+
+// Build validates the graph, and returns the task, that runs the workflow
+// nodes on every tick with maximal parallelism, each node starting when all
+// its dependencies have finished. The task returns the joined errors of the
+// failed nodes, each prefixed with the node name.
+//
+// A node error, matching [utils.ErrStopped], is kept in the returned error, so
+// it stops the task loop after the workflow run, as for a single task. The
+// other nodes of the run are not interrupted.
+func (w *Workflow[TickType]) Build() (func(context.Context, TickType) error, error) {
+	index := make(map[string]int, len(w.nodes))
+	for i, n := range w.nodes {
+		if _, ok := index[n.name]; ok {
+			return nil, fmt.Errorf("duplicate workflow node %q", n.name)
+		}
+		index[n.name] = i
+	}
+	deps := make([][]int, len(w.nodes))
+	for i, n := range w.nodes {
+		for _, dep := range n.deps {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("workflow node %q depends on unknown node %q", n.name, dep)
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+	if cycle := findCycle(deps); cycle >= 0 {
+		return nil, fmt.Errorf("workflow node %q is in a dependency cycle", w.nodes[cycle].name)
+	}
+	nodes := w.nodes
+	policy := w.policy
+	return func(ctx context.Context, tick TickType) error {
+		done := make([]chan struct{}, len(nodes))
+		failed := make([]bool, len(nodes))
+		errs := make([]error, len(nodes))
+		for i := range nodes {
+			done[i] = make(chan struct{})
+		}
+		var wg sync.WaitGroup
+		for i, n := range nodes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer close(done[i])
+				skip := false
+				for _, j := range deps[i] {
+					<-done[j]
+					skip = skip || failed[j]
+				}
+				if skip && policy == SkipDependants {
+					failed[i] = true
+					return
+				}
+				if err := n.task(ctx, tick); err != nil {
+					failed[i] = true
+					errs[i] = fmt.Errorf("%s: %w", n.name, err)
+				}
+			}()
+		}
+		wg.Wait()
+		return errors.Join(errs...)
+	}, nil
+}
+
+// findCycle returns the index of a node in a dependency cycle, or -1.
+func findCycle(deps [][]int) int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(deps))
+	cycle := -1
+	var visit func(int) bool
+	visit = func(i int) bool {
+		switch state[i] {
+		case visiting:
+			cycle = i
+			return true
+		case visited:
+			return false
+		}
+		state[i] = visiting
+		for _, j := range deps[i] {
+			if visit(j) {
+				return true
+			}
+		}
+		state[i] = visited
+		return false
+	}
+	for i := range deps {
+		if visit(i) {
+			return cycle
+		}
+	}
+	return -1
+}
@@ -0,0 +1,126 @@
+package goticks
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/parametalol/curry/assert"
+	"github.com/parametalol/goticks/utils"
+)
+
+type runLog struct {
+	mux  sync.Mutex
+	runs []string
+}
+
+func (l *runLog) node(name string, err error) func(context.Context, int) error {
+	return func(context.Context, int) error {
+		l.mux.Lock()
+		defer l.mux.Unlock()
+		l.runs = append(l.runs, name)
+		return err
+	}
+}
+
+func TestWorkflow(t *testing.T) {
+	errTest := errors.New("test")
+
+	t.Run("diamond", func(t *testing.T) {
+		log := &runLog{}
+		wf, err := NewWorkflow[int](SkipDependants).
+			Add("d", log.node("d", nil), DependsOn("b", "c")).
+			Add("b", log.node("b", nil), DependsOn("a")).
+			Add("c", log.node("c", nil), DependsOn("a")).
+			Add("a", log.node("a", nil)).
+			Build()
+		assert.That(t,
+			assert.NoError(err),
+			assert.NoError(wf(context.Background(), 0)),
+			assert.Equal(4, len(log.runs)),
+			assert.Equal("a", log.runs[0]),
+			assert.Equal("d", log.runs[3]))
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		barrier := make(chan struct{})
+		wait := func(context.Context, int) error {
+			<-barrier
+			return nil
+		}
+		wf, err := NewWorkflow[int](SkipDependants).
+			Add("a", wait).
+			Add("b", wait).
+			Add("c", func(context.Context, int) error {
+				close(barrier)
+				return nil
+			}).
+			Build()
+		assert.That(t,
+			assert.NoError(err),
+			assert.NoError(wf(context.Background(), 0)))
+	})
+
+	t.Run("skip dependants", func(t *testing.T) {
+		log := &runLog{}
+		wf, _ := NewWorkflow[int](SkipDependants).
+			Add("a", log.node("a", errTest)).
+			Add("b", log.node("b", nil), DependsOn("a")).
+			Add("c", log.node("c", nil), DependsOn("b")).
+			Add("d", log.node("d", nil)).
+			Build()
+		err := wf(context.Background(), 0)
+		slices.Sort(log.runs)
+		assert.That(t,
+			assert.ErrorIs(err, errTest),
+			assert.Equal("a: test", err.Error()),
+			assert.EqualSlices([]string{"a", "d"}, log.runs))
+	})
+
+	t.Run("continue on failure", func(t *testing.T) {
+		log := &runLog{}
+		wf, _ := NewWorkflow[int](ContinueOnFailure).
+			Add("a", log.node("a", errTest)).
+			Add("b", log.node("b", utils.ErrStopped), DependsOn("a")).
+			Add("c", log.node("c", nil), DependsOn("b")).
+			Build()
+		err := wf(context.Background(), 0)
+		assert.That(t,
+			assert.ErrorIs(err, errTest),
+			assert.ErrorIs(err, utils.ErrStopped),
+			assert.EqualSlices([]string{"a", "b", "c"}, log.runs))
+	})
+
+	t.Run("node options", func(t *testing.T) {
+		attempts := 0
+		wf, _ := NewWorkflow[int](SkipDependants).
+			Add("a", func(ctx context.Context, _ int) error {
+				attempts++
+				<-ctx.Done()
+				return ctx.Err()
+			}, NodeTimeout(10*time.Millisecond), NodeRetry(utils.SimpleRetryPolicy(2))).
+			Build()
+		err := wf(context.Background(), 0)
+		assert.That(t,
+			assert.ErrorIs(err, context.DeadlineExceeded),
+			assert.Equal(2, attempts))
+	})
+
+	t.Run("build errors", func(t *testing.T) {
+		nop := func(context.Context, int) error { return nil }
+		_, errDup := NewWorkflow[int](SkipDependants).Add("a", nop).Add("a", nop).Build()
+		_, errUnknown := NewWorkflow[int](SkipDependants).Add("a", nop, DependsOn("b")).Build()
+		_, errCycle := NewWorkflow[int](SkipDependants).
+			Add("a", nop).
+			Add("b", nop, DependsOn("a", "c")).
+			Add("c", nop, DependsOn("b")).
+			Build()
+		assert.That(t,
+			assert.Equal(`duplicate workflow node "a"`, errDup.Error()),
+			assert.Equal(`workflow node "a" depends on unknown node "b"`, errUnknown.Error()),
+			assert.Equal(`workflow node "b" is in a dependency cycle`, errCycle.Error()))
+	})
+}